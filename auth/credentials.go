@@ -2,10 +2,13 @@ package auth
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type Credentials struct {
@@ -26,15 +29,18 @@ func NewCredentials(clientID, clientSecret string, scopes ...Scope) *Credentials
 	}
 }
 
-// BuildAuthFormData returns the form data for the credentials
-func (c *Credentials) BuildAuthFormData() map[string]string {
+// BuildAuthFormData returns the form data for the credentials.
+// When scopes are given, they are requested instead of the scopes of the credentials.
+func (c *Credentials) BuildAuthFormData(scopes ...Scope) map[string]string {
 	data := map[string]string{
 		"client_id":     c.clientID,
 		"client_secret": c.clientSecret,
 		"grant_type":    c.grantType,
 	}
 
-	if c.HasScopes() {
+	if len(scopes) > 0 {
+		data["scope"] = JoinScopes(scopes)
+	} else if c.HasScopes() {
 		data["scope"] = c.GetScopesString()
 	}
 
@@ -113,6 +119,34 @@ func (c *Credentials) LoadCertAndKeyFromPath(certPath, keyPath string) error {
 	return nil
 }
 
+// GetCertificateExpiresAt returns the expiration time of the TLS certificate, if one is loaded
+func (c *Credentials) GetCertificateExpiresAt() (time.Time, bool) {
+	if c.cert == nil || len(c.cert.Certificate) == 0 {
+		return time.Time{}, false
+	}
+
+	leaf := c.cert.Leaf
+	if leaf == nil {
+		// O certificado de SetTLS pode estar em PEM
+		der := c.cert.Certificate[0]
+		if block, _ := pem.Decode(der); block != nil {
+			der = block.Bytes
+		}
+
+		var err error
+		if leaf, err = x509.ParseCertificate(der); err != nil {
+			return time.Time{}, false
+		}
+	}
+
+	return leaf.NotAfter, true
+}
+
+// GetClientID returns the client ID
+func (c *Credentials) GetClientID() string {
+	return c.clientID
+}
+
 // HasScopes returns true if the credentials have scopes
 func (c *Credentials) HasScopes() bool {
 	return len(c.scopes) > 0
@@ -125,11 +159,16 @@ func (c *Credentials) GetScopes() []Scope {
 
 // GetScopesString returns the scopes as a space-separated string
 func (c *Credentials) GetScopesString() string {
-	scopes := make([]string, len(c.scopes))
-	for i, scope := range c.scopes {
-		scopes[i] = string(scope)
+	return JoinScopes(c.scopes)
+}
+
+// JoinScopes returns the scopes as a space-separated string
+func JoinScopes(scopes []Scope) string {
+	s := make([]string, len(scopes))
+	for i, scope := range scopes {
+		s[i] = string(scope)
 	}
-	return strings.Join(scopes, " ")
+	return strings.Join(s, " ")
 }
 
 /*
@@ -149,6 +188,8 @@ cob.write - Emissão / alteração de pix cobrança imediata
 cob.read - Consulta de pix cobrança imediata
 cobv.write - Emissão / alteração de pix cobrança com vencimento
 cobv.read - Consulta de cobrança com vencimento
+lotecobv.write - Alteração de lotes de cobranças com vencimento
+lotecobv.read - Consulta de lotes de cobranças com vencimento
 pix.write - Solicitação de devolução de pix
 pix.read - Consulta de pix
 webhook.read - Consulta do webhook
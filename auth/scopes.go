@@ -13,6 +13,8 @@ const (
 	ScopeCobRead              Scope = "cob.read"               // Consulta de pix cobrança imediata
 	ScopeCobVWrite            Scope = "cobv.write"             // Emissão / alteração de pix cobrança com vencimento
 	ScopeCobVRead             Scope = "cobv.read"              // Consulta de cobrança com vencimento
+	ScopeLoteCobVWrite        Scope = "lotecobv.write"         // Alteração de lotes de cobranças com vencimento
+	ScopeLoteCobVRead         Scope = "lotecobv.read"          // Consulta de lotes de cobranças com vencimento
 	ScopePixWrite             Scope = "pix.write"              // Solicitação de devolução de pix
 	ScopePixRead              Scope = "pix.read"               // Consulta de pix
 	ScopeWebhookRead          Scope = "webhook.read"           // Consulta do webhook
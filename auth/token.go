@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -21,6 +22,11 @@ func (t *Token) SetExpiresAt(expiresAt time.Time) *Token {
 	return t
 }
 
+// GetExpiresAt returns the expiration time
+func (t Token) GetExpiresAt() time.Time {
+	return t.expiresAt
+}
+
 // Valid returns true if the token is still valid
 func (t Token) Valid() bool {
 	return t.expiresAt.After(time.Now())
@@ -35,3 +41,45 @@ func (t Token) GetAccessToken() string {
 func (t Token) GetAuthorization() string {
 	return fmt.Sprintf("%s %s", t.Type, t.AccessToken)
 }
+
+// tokenJSON is the serializable form of the token, including the expiration time
+type tokenJSON struct {
+	AccessToken string     `json:"access_token"`
+	Type        string     `json:"token_type"`
+	Scope       string     `json:"scope"`
+	ExpiresIn   int64      `json:"expires_in"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// MarshalJSON serializes the token including its expiration time, so it can be shared through a token store
+func (t Token) MarshalJSON() ([]byte, error) {
+	v := tokenJSON{
+		AccessToken: t.AccessToken,
+		Type:        t.Type,
+		Scope:       t.Scope,
+		ExpiresIn:   t.ExpiresIn,
+	}
+	if !t.expiresAt.IsZero() {
+		v.ExpiresAt = &t.expiresAt
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON parses the token, with or without the expiration time (the OAuth response has none)
+func (t *Token) UnmarshalJSON(data []byte) error {
+	var v tokenJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*t = Token{
+		AccessToken: v.AccessToken,
+		Type:        v.Type,
+		Scope:       v.Scope,
+		ExpiresIn:   v.ExpiresIn,
+	}
+	if v.ExpiresAt != nil {
+		t.expiresAt = *v.ExpiresAt
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// AccountHeader é o header que seleciona a conta corrente da requisição
+const AccountHeader = "x-conta-corrente"
+
+type accountKey struct{}
+
+// WithAccount returns a context whose calls are made on the given current account,
+// overriding the default account of the client
+func WithAccount(ctx context.Context, account string) context.Context {
+	return context.WithValue(ctx, accountKey{}, account)
+}
+
+// AccountFromContext returns the current account set in the context with WithAccount
+func AccountFromContext(ctx context.Context) (string, bool) {
+	account, ok := ctx.Value(accountKey{}).(string)
+	return account, ok && account != ""
+}
+
+// SetAccountNumber sets the default current account, sent in each request that has no other account
+func (c *BackendImplement) SetAccountNumber(account string) *BackendImplement {
+	c.account.Store(account)
+	return c
+}
+
+// GetAccountNumber returns the default current account
+func (c *BackendImplement) GetAccountNumber() string {
+	account, _ := c.account.Load().(string)
+	return account
+}
+
+// ForAccount returns a view of the backend whose requests use the given current account.
+// The view shares the HTTP client, the tokens and the settings of the backend.
+func (c *BackendImplement) ForAccount(account string) Backend {
+	return &accountBackend{BackendImplement: c, account: account}
+}
+
+// accountBackend is a view of the backend bound to a current account
+type accountBackend struct {
+	*BackendImplement
+	account string
+}
+
+// Req returns a new request with the account of the view
+func (b *accountBackend) Req() *resty.Request {
+	return b.BackendImplement.Req().SetHeader(AccountHeader, b.account)
+}
+
+// Do sends the call with the account of the view, unless the context sets another one
+func (b *accountBackend) Do(ctx context.Context, call *Call) error {
+	if _, ok := AccountFromContext(ctx); !ok {
+		ctx = WithAccount(ctx, b.account)
+	}
+	return b.BackendImplement.Do(ctx, call)
+}
+
+// applyAccount sets the account of the request context, if any
+func applyAccount(req *resty.Request) {
+	if account, ok := AccountFromContext(req.Context()); ok {
+		req.SetHeader(AccountHeader, account)
+	}
+}
@@ -2,33 +2,76 @@ package backend
 
 import (
 	"context"
-	"fmt"
-	"path"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/raniellyferreira/interbank-go/auth"
-	"github.com/raniellyferreira/interbank-go/erros"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
 	oauthEndpoint = "oauth/v2"
+
+	// ProductionURL is the base URL of the production environment
+	ProductionURL = "https://cdpj.partners.bancointer.com.br"
+
+	// SandboxURL is the base URL of the sandbox environment
+	SandboxURL = "https://cdpj-sandbox.partners.uatinter.co"
 )
 
-// type Backend interface {
-// 	SetURL(url string) Backend
-// 	SetHeader(header, value string) Backend
-// 	Req() *resty.Request
-// 	Token(ctx context.Context) (*auth.Token, error)
-// }
+// Backend is the transport used by the services: it builds requests against the base URL
+// and provides the access token. BackendImplement is the default implementation.
+type Backend interface {
+	// Req returns a new request for the backend client
+	Req() *resty.Request
+
+	// Execute sends the request, retrying transient failures according to the retry policy
+	Execute(req *resty.Request, method, url string) (*resty.Response, error)
+
+	// Do sends the call of a service method through the middleware chain
+	Do(ctx context.Context, call *Call) error
+
+	// Token returns the current token of the scopes or requests a new one
+	Token(ctx context.Context, scopes ...auth.Scope) (*auth.Token, error)
+
+	// GetURL returns the base URL of the backend
+	GetURL() string
+
+	// IsProduction returns true if the base URL points to the production environment
+	IsProduction() bool
+}
+
+var _ Backend = (*BackendImplement)(nil)
 
 type BackendImplement struct {
 	client *resty.Client
 	creds  *auth.Credentials
-
-	token *auth.Token
-	tmu   sync.Mutex
+	retry  RetryPolicy
+
+	limiter *RateLimiter
+	account atomic.Value // Conta corrente padrão (string)
+
+	logger      *slog.Logger
+	correlation []string // Headers de correlação incluídos no log
+	tracer      trace.Tracer
+	metrics     Metrics
+	middlewares atomic.Pointer[[]Middleware] // Trocada inteira por Use e SetMiddlewares, lida por chamada
+
+	tokens   sync.Map     // Tokens por conjunto de escopos (string -> *tokenState)
+	scoped   atomic.Bool  // Solicita um token por conjunto de escopos
+	invalid  atomic.Value // Último access token invalidado (string)
+	refresh  singleflight.Group
+	renewal  tokenRenewal
+	store    TokenStore
+	leaseTTL time.Duration
 }
 
 // NewBackendWithCredentials creates a new backend with the given credentials
@@ -41,10 +84,26 @@ func NewBackendWithCredentials(creds *auth.Credentials) *BackendImplement {
 		client.SetTLSClientConfig(tls)
 	}
 
-	return &BackendImplement{
+	c := &BackendImplement{
 		client: client,
 		creds:  creds,
+		retry:  DefaultRetryPolicy,
+
+		limiter: NewRateLimiter(DefaultRateLimits),
+		tracer:  noop.NewTracerProvider().Tracer(TracerName),
+
+		renewal: tokenRenewal{
+			enabled: true,
+			skew:    DefaultTokenSkew,
+			jitter:  DefaultTokenJitter,
+		},
+		store:    NewMemoryTokenStore(),
+		leaseTTL: DefaultTokenLeaseTTL,
 	}
+	c.SetMiddlewares(c.DefaultMiddlewares()...)
+	c.scoped.Store(true)
+
+	return c
 }
 
 // SetTimeout sets the timeout for the backend
@@ -59,58 +118,49 @@ func (c *BackendImplement) SetURL(url string) *BackendImplement {
 	return c
 }
 
-// SetHeader sets a header for the backend
-func (c *BackendImplement) SetHeader(header, value string) *BackendImplement {
-	c.client.SetHeader(header, value)
-	return c
-}
-
-// Req get request
-func (c *BackendImplement) Req() *resty.Request {
-	return c.client.R()
+// GetURL returns the base URL of the backend
+func (c *BackendImplement) GetURL() string {
+	return c.client.BaseURL
 }
 
-// Token returns the current token or requests a new one
-func (ts *BackendImplement) Token(ctx context.Context) (*auth.Token, error) {
-	ts.tmu.Lock()
-	defer ts.tmu.Unlock()
-
-	if ts.token == nil || !ts.token.Valid() {
-		token, err := ts.requestNewToken(ctx)
-		if err != nil {
-			return nil, err
-		}
+// IsProduction returns true if the base URL points to the production environment
+func (c *BackendImplement) IsProduction() bool {
+	base, err := url.Parse(c.client.BaseURL)
+	if err != nil {
+		return true
+	}
 
-		ts.token = token
+	production, _ := url.Parse(ProductionURL)
+	return strings.EqualFold(base.Hostname(), production.Hostname())
+}
 
-		// Set the expiration time
-		ts.token.SetExpiresAt(time.Now().Add(time.Duration(ts.token.ExpiresIn-180) * time.Second))
-	}
+// GetCredentials returns the credentials of the backend
+func (c *BackendImplement) GetCredentials() *auth.Credentials {
+	return c.creds
+}
 
-	return ts.token, nil
+// Transport returns the HTTP transport of the backend, or an error if a custom round tripper is set
+func (c *BackendImplement) Transport() (*http.Transport, error) {
+	return c.client.Transport()
 }
 
-// requestNewToken requests a new token
-func (ts *BackendImplement) requestNewToken(ctx context.Context) (*auth.Token, error) {
-	// Send the request
-	resp, err := ts.Req().
-		SetContext(ctx).
-		SetResult(&auth.Token{}).
-		SetFormData(ts.creds.BuildAuthFormData()).
-		Post(path.Join(oauthEndpoint, "token"))
-	if err != nil {
-		return nil, erros.NewFromError(err)
-	}
+// SetTransport sets the HTTP transport (round tripper) of the backend
+func (c *BackendImplement) SetTransport(transport http.RoundTripper) *BackendImplement {
+	c.client.SetTransport(transport)
+	return c
+}
 
-	// Check for errors
-	if resp.IsError() {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
+// SetHeader sets a header for the backend
+func (c *BackendImplement) SetHeader(header, value string) *BackendImplement {
+	c.client.SetHeader(header, value)
+	return c
+}
 
-	token, ok := resp.Result().(*auth.Token)
-	if !ok {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), fmt.Sprintf("invalid response type: %T", resp.Result()))
+// Req get request, with the default current account when set
+func (c *BackendImplement) Req() *resty.Request {
+	req := c.client.R()
+	if account := c.GetAccountNumber(); account != "" {
+		req.SetHeader(AccountHeader, account)
 	}
-
-	return token, nil
+	return req
 }
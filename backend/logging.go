@@ -0,0 +1,148 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// maxLogBody é o tamanho máximo dos corpos incluídos no log (os exports em PDF são grandes)
+const maxLogBody = 4096
+
+// DefaultCorrelationHeaders são os headers de correlação da resposta incluídos no log de cada chamada
+var DefaultCorrelationHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Trace-Id"}
+
+// SetLogger sets the logger of the calls (nil disables the logging, the default).
+// Each attempt is logged with the operation (service method), the HTTP method, the endpoint template
+// (e.g. pix/v2/cob/{txid}), the status, the latency, the attempt number and the correlation headers:
+// at info level, at warn level for error responses and at error level for transport failures (logged without
+// the URL of the request). When the logger is enabled for debug, the
+// request and response bodies are included, without secrets, tokens and CPF/CNPJ numbers.
+func (c *BackendImplement) SetLogger(logger *slog.Logger) *BackendImplement {
+	c.logger = logger
+	return c
+}
+
+// SetCorrelationHeaders sets the response headers included in the log of each call
+// (default DefaultCorrelationHeaders)
+func (c *BackendImplement) SetCorrelationHeaders(headers ...string) *BackendImplement {
+	c.correlation = headers
+	return c
+}
+
+// logAttempt logs one attempt of a call
+func (c *BackendImplement) logAttempt(req *resty.Request, method, endpoint string, attempt int, resp *resty.Response, err error, latency time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	ctx := req.Context()
+
+	level := slog.LevelInfo
+	switch {
+	case err != nil:
+		level = slog.LevelError
+	case resp != nil && resp.IsError():
+		level = slog.LevelWarn
+	}
+
+	if !c.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := make([]slog.Attr, 0, 10)
+	if operation := OperationFromContext(ctx); operation != "" {
+		attrs = append(attrs, slog.String("operation", operation))
+	}
+
+	attrs = append(attrs,
+		slog.String("method", method),
+		slog.String("endpoint", endpoint),
+		slog.Int("status", statusCode(resp)),
+		slog.Duration("latency", latency),
+		slog.Int("attempt", attempt),
+	)
+
+	if resp != nil && resp.RawResponse != nil {
+		headers := c.correlation
+		if headers == nil {
+			headers = DefaultCorrelationHeaders
+		}
+		for _, header := range headers {
+			if value := resp.Header().Get(header); value != "" {
+				attrs = append(attrs, slog.String(strings.ToLower(header), value))
+			}
+		}
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", logError(err)))
+	}
+
+	if c.logger.Enabled(ctx, slog.LevelDebug) {
+		if body := requestBody(req); len(body) > 0 {
+			attrs = append(attrs, slog.String("request_body", truncateBody(body)))
+		}
+		if resp != nil && len(resp.Body()) > 0 {
+			attrs = append(attrs, slog.String("response_body", truncateBody(RedactBody(resp.Body()))))
+		}
+	}
+
+	c.logger.LogAttrs(ctx, level, "interbank request", attrs...)
+}
+
+// logError returns the error without the URL of the request, whose query may carry CPF/CNPJ numbers
+// (e.g. "Get: dial tcp: connection refused" instead of the *url.Error text)
+func logError(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Op + ": " + urlErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// requestBody returns the redacted body of the request
+func requestBody(req *resty.Request) []byte {
+	if len(req.FormData) > 0 {
+		return []byte(redactForm(req.FormData).Encode())
+	}
+
+	var body []byte
+	switch b := req.Body.(type) {
+	case nil:
+		return nil
+	case []byte:
+		body = b
+	case string:
+		body = []byte(b)
+	default:
+		var err error
+		if body, err = json.Marshal(b); err != nil {
+			return []byte(fmt.Sprintf("(%T)", b))
+		}
+	}
+
+	return RedactBody(body)
+}
+
+// truncateBody limits the size of the body in the log
+func truncateBody(body []byte) string {
+	if len(body) > maxLogBody {
+		return fmt.Sprintf("%s... (%d bytes)", body[:maxLogBody], len(body))
+	}
+	return string(body)
+}
+
+// statusCode returns the status of the response, or zero on transport failures
+func statusCode(resp *resty.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode()
+}
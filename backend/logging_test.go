@@ -0,0 +1,39 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestLogTransportErrorWithoutURL(t *testing.T) {
+	// Fecha a conexão sem resposta: o erro de transporte do resty é um *url.Error com a URL da chamada
+	b := newTestBackend(t, func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	})
+
+	var buf bytes.Buffer
+	b.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	ctx := WithMaxAttempts(context.Background(), 1)
+	err := b.Do(ctx, &Call{Operation: "test", Method: resty.MethodGet, Endpoint: "pix/v2/cob", Query: map[string]string{"cpf": "12345678909"}})
+	if err == nil {
+		t.Fatal("Do() error = nil, want a transport error")
+	}
+
+	log := buf.String()
+	if !strings.Contains(log, "level=ERROR") || !strings.Contains(log, "error=") {
+		t.Fatalf("log without the transport error: %s", log)
+	}
+	if strings.Contains(log, "12345678909") {
+		t.Errorf("log contains the query of the request: %s", log)
+	}
+}
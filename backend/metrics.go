@@ -0,0 +1,81 @@
+package backend
+
+import (
+	"strings"
+	"time"
+)
+
+// Metrics recebe os eventos do backend para coleta de métricas.
+// As chamadas são identificadas pela operação (por exemplo "pix.CriarCobrancaImediata", veja SplitOperation).
+// PrometheusMetrics é a implementação padrão; os métodos devem ser seguros para uso concorrente.
+type Metrics interface {
+	// ObserveRequest is called after each HTTP request of a call (status zero on transport failures)
+	ObserveRequest(operation string, status int, err error, latency time.Duration)
+
+	// ObserveRetry is called before each new attempt of a call
+	ObserveRetry(operation string)
+
+	// ObserveRateLimit is called when a call waited for the rate limiter, or was rejected by it (err not nil)
+	ObserveRateLimit(group string, wait time.Duration, err error)
+
+	// ObserveTokenRefresh is called after each token refresh
+	ObserveTokenRefresh(latency time.Duration, err error)
+
+	// ObserveCertificateExpiry is called with the expiration time of the mTLS certificate of the credentials
+	ObserveCertificateExpiry(clientID string, expiresAt time.Time)
+}
+
+// SplitOperation returns the service and the method of an operation (e.g. "pix" and "CriarCobrancaImediata")
+func SplitOperation(operation string) (service, method string) {
+	if operation == "" {
+		return "unknown", "unknown"
+	}
+	if service, method, ok := strings.Cut(operation, "."); ok {
+		return service, method
+	}
+	return "unknown", operation
+}
+
+// StatusClass returns the class of the status ("2xx", "4xx", ...) or "error" on transport failures
+func StatusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "error"
+	}
+	return string(rune('0'+status/100)) + "xx"
+}
+
+// SetMetrics sets the metrics collector of the backend (nil disables the metrics, the default)
+func (c *BackendImplement) SetMetrics(metrics Metrics) *BackendImplement {
+	c.metrics = metrics
+
+	if metrics != nil {
+		if expiresAt, ok := c.creds.GetCertificateExpiresAt(); ok {
+			metrics.ObserveCertificateExpiry(c.creds.GetClientID(), expiresAt)
+		}
+	}
+	return c
+}
+
+func (c *BackendImplement) observeRequest(operation string, status int, err error, latency time.Duration) {
+	if c.metrics != nil {
+		c.metrics.ObserveRequest(operation, status, err, latency)
+	}
+}
+
+func (c *BackendImplement) observeRetry(operation string) {
+	if c.metrics != nil {
+		c.metrics.ObserveRetry(operation)
+	}
+}
+
+func (c *BackendImplement) observeRateLimit(group string, wait time.Duration, err error) {
+	if c.metrics != nil && group != "" && (wait > 0 || err != nil) {
+		c.metrics.ObserveRateLimit(group, wait, err)
+	}
+}
+
+func (c *BackendImplement) observeTokenRefresh(latency time.Duration, err error) {
+	if c.metrics != nil {
+		c.metrics.ObserveTokenRefresh(latency, err)
+	}
+}
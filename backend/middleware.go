@@ -0,0 +1,176 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
+	"github.com/raniellyferreira/interbank-go/erros"
+)
+
+// Call descreve uma chamada de um método dos serviços à API do Inter
+type Call struct {
+	Operation  string            // Nome da operação, "serviço.Método" (por exemplo "pix.CriarCobrancaImediata")
+	Method     string            // Método HTTP
+	Endpoint   string            // Template do endpoint, com os path params entre chaves (por exemplo "pix/v2/cob/{txid}")
+	PathParams map[string]string // Valores dos path params do endpoint
+	Query      map[string]string // Query params (os vazios são omitidos)
+	Scopes     []auth.Scope      // Escopos exigidos pela operação
+	Body       any               // Corpo enviado como JSON (nil para nenhum)
+	Result     any               // Destino da resposta de sucesso (nil para ignorar)
+	Idempotent bool              // A operação pode ser repetida sem efeitos colaterais (veja WithIdempotent)
+
+	Request *resty.Request // Requisição montada por Do, que os middlewares podem alterar
+}
+
+// RoundTrip envia a chamada e retorna a resposta
+type RoundTrip func(ctx context.Context, call *Call) (*resty.Response, error)
+
+// Middleware envolve o RoundTrip seguinte da cadeia, podendo alterar a chamada, a resposta e o erro,
+// ou responder sem chamar o próximo (cache, testes de caos). Uma resposta nil sem erro é tratada
+// como sucesso sem corpo.
+type Middleware func(next RoundTrip) RoundTrip
+
+// Do sends the call through the middleware chain and decodes the result into call.Result.
+// The call span is started before the chain (see StartCall) and the last step is Execute,
+// which applies the rate limiting and the retry policy.
+func (c *BackendImplement) Do(ctx context.Context, call *Call) error {
+	ctx, end := c.StartCall(ctx, call.Operation)
+	defer end()
+
+	if call.Idempotent {
+		ctx = WithIdempotent(ctx)
+	}
+
+	call.Request = c.Req().
+		SetContext(ctx).
+		SetError(&erros.Response{}).
+		SetPathParams(call.PathParams)
+
+	for key, value := range call.Query {
+		if value != "" {
+			call.Request.SetQueryParam(key, value)
+		}
+	}
+
+	if call.Body != nil {
+		call.Request.
+			SetHeader("Content-Type", "application/json").
+			SetBody(call.Body)
+	}
+
+	if call.Result != nil {
+		call.Request.SetResult(call.Result)
+	}
+
+	_, err := c.chain()(ctx, call)
+	return err
+}
+
+// Use adds middlewares to the end of the chain, after the built-in ones: they receive the authenticated
+// request and the response before the error decoding. Safe to call concurrently with the calls, which
+// use the chain in place when they start.
+func (c *BackendImplement) Use(middlewares ...Middleware) *BackendImplement {
+	for {
+		current := c.middlewares.Load()
+		chain := slices.Clone(*current)
+		chain = append(chain, middlewares...)
+		if c.middlewares.CompareAndSwap(current, &chain) {
+			return c
+		}
+	}
+}
+
+// SetMiddlewares replaces the whole chain, including the built-in middlewares (DecodeErrors and Authenticate).
+// The first middleware is the outermost.
+func (c *BackendImplement) SetMiddlewares(middlewares ...Middleware) *BackendImplement {
+	chain := slices.Clone(middlewares)
+	c.middlewares.Store(&chain)
+	return c
+}
+
+// Middlewares returns the current chain, the first middleware being the outermost
+func (c *BackendImplement) Middlewares() []Middleware {
+	return slices.Clone(*c.middlewares.Load())
+}
+
+// DefaultMiddlewares returns the built-in chain: DecodeErrors and Authenticate
+func (c *BackendImplement) DefaultMiddlewares() []Middleware {
+	return []Middleware{DecodeErrors, c.Authenticate()}
+}
+
+// chain returns the middlewares wrapped around Execute
+func (c *BackendImplement) chain() RoundTrip {
+	rt := RoundTrip(func(_ context.Context, call *Call) (*resty.Response, error) {
+		return c.Execute(call.Request, call.Method, call.Endpoint)
+	})
+
+	middlewares := *c.middlewares.Load()
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
+
+// DecodeErrors converts the failures into *erros.Response: errors that already are one keep their
+// status (e.g. a 401 from the token endpoint), other errors become transport errors keeping the cause,
+// and error responses are decoded from the body
+func DecodeErrors(next RoundTrip) RoundTrip {
+	return func(ctx context.Context, call *Call) (*resty.Response, error) {
+		resp, err := next(ctx, call)
+		if err != nil {
+			var errResp *erros.Response
+			if errors.As(err, &errResp) {
+				return resp, errResp
+			}
+			return resp, erros.NewTransportError(err)
+		}
+
+		// Um middleware respondeu sem resposta HTTP: sucesso sem corpo
+		if resp == nil {
+			return nil, nil
+		}
+
+		if resp.IsError() {
+			if errResp, ok := resp.Error().(*erros.Response); ok {
+				return resp, errResp.WithStatus(resp.StatusCode())
+			}
+			return resp, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+		}
+
+		return resp, nil
+	}
+}
+
+// Authenticate sets the token of the call scopes in the request.
+// A 401 invalidates the token and the call is sent once more with a new one.
+func (c *BackendImplement) Authenticate() Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (*resty.Response, error) {
+			// Falhas do token endpoint mantêm o status (401/403 da autenticação, não erro de transporte)
+			token, err := c.Token(ctx, call.Scopes...)
+			if err != nil {
+				return nil, err
+			}
+			call.Request.SetAuthToken(token.GetAccessToken())
+
+			resp, err := next(ctx, call)
+			if err != nil || resp == nil || resp.StatusCode() != http.StatusUnauthorized {
+				return resp, err
+			}
+
+			// Token revogado ou expirado no servidor: renova e repete uma única vez
+			c.InvalidateToken(token.GetAccessToken())
+			if token, err = c.Token(ctx, call.Scopes...); err != nil {
+				return resp, nil
+			}
+
+			c.observeRetry(call.Operation)
+			call.Request.SetAuthToken(token.GetAccessToken())
+			return next(ctx, call)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package backend
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestUseConcurrentWithCalls(t *testing.T) {
+	handler, _ := failFirst(0, 0, nil)
+	b := newTestBackend(t, handler)
+
+	var seen atomic.Int32
+	counter := func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (*resty.Response, error) {
+			seen.Add(1)
+			return next(ctx, call)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			b.Use(counter)
+		}()
+		go func() {
+			defer wg.Done()
+			if err := b.Do(context.Background(), &Call{Operation: "test", Method: resty.MethodGet, Endpoint: "pix/v2/cob"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Nenhum Use concorrente é perdido
+	if got := len(b.Middlewares()); got != len(b.DefaultMiddlewares())+10 {
+		t.Fatalf("len(Middlewares()) = %d, want %d", got, len(b.DefaultMiddlewares())+10)
+	}
+
+	seen.Store(0)
+	if err := b.Do(context.Background(), &Call{Operation: "test", Method: resty.MethodGet, Endpoint: "pix/v2/cob"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := seen.Load(); got != 10 {
+		t.Errorf("middleware calls = %d, want 10", got)
+	}
+}
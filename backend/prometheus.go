@@ -0,0 +1,302 @@
+package backend
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets são os limites (em segundos) do histograma de latência das requisições
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// PrometheusMetrics coleta as métricas do backend e as expõe no formato texto do Prometheus,
+// sem dependências externas. Pode ser compartilhado por vários clientes (por exemplo, os de um inter.Pool).
+//
+// Métricas:
+//
+//	interbank_requests_total{service,method,status_class}
+//	interbank_request_duration_seconds{service,method} (histograma)
+//	interbank_retries_total{service,method}
+//	interbank_rate_limit_waits_total{group}
+//	interbank_rate_limit_wait_seconds_total{group}
+//	interbank_rate_limit_rejections_total{group}
+//	interbank_token_refreshes_total
+//	interbank_token_refresh_failures_total
+//	interbank_token_refresh_duration_seconds (histograma)
+//	interbank_tls_certificate_expiry_days{client_id}
+type PrometheusMetrics struct {
+	mu      sync.Mutex
+	buckets []float64
+
+	requests   map[[3]string]uint64     // service, method, status class
+	latencies  map[[2]string]*histogram // service, method
+	retries    map[[2]string]uint64     // service, method
+	waits      map[string]uint64
+	waitTime   map[string]float64
+	rejections map[string]uint64
+
+	refreshes       uint64
+	refreshFailures uint64
+	refreshLatency  *histogram
+
+	certificates map[string]time.Time // client ID -> expiração
+}
+
+var (
+	_ Metrics      = (*PrometheusMetrics)(nil)
+	_ http.Handler = (*PrometheusMetrics)(nil)
+)
+
+// NewPrometheusMetrics creates a new collector with the default latency buckets
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		buckets:        DefaultLatencyBuckets,
+		requests:       make(map[[3]string]uint64),
+		latencies:      make(map[[2]string]*histogram),
+		retries:        make(map[[2]string]uint64),
+		waits:          make(map[string]uint64),
+		waitTime:       make(map[string]float64),
+		rejections:     make(map[string]uint64),
+		refreshLatency: newHistogram(DefaultLatencyBuckets),
+		certificates:   make(map[string]time.Time),
+	}
+}
+
+// SetBuckets sets the latency buckets in seconds; must be called before the first observation
+func (m *PrometheusMetrics) SetBuckets(buckets ...float64) *PrometheusMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.buckets = slices.Clone(buckets)
+	slices.Sort(m.buckets)
+	m.refreshLatency = newHistogram(m.buckets)
+	return m
+}
+
+// ObserveRequest counts the request and observes its latency
+func (m *PrometheusMetrics) ObserveRequest(operation string, status int, _ error, latency time.Duration) {
+	service, method := SplitOperation(operation)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[[3]string{service, method, StatusClass(status)}]++
+
+	key := [2]string{service, method}
+	h, ok := m.latencies[key]
+	if !ok {
+		h = newHistogram(m.buckets)
+		m.latencies[key] = h
+	}
+	h.observe(latency.Seconds())
+}
+
+// ObserveRetry counts the retry
+func (m *PrometheusMetrics) ObserveRetry(operation string) {
+	service, method := SplitOperation(operation)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retries[[2]string{service, method}]++
+}
+
+// ObserveRateLimit counts the wait or the rejection of the rate limiter
+func (m *PrometheusMetrics) ObserveRateLimit(group string, wait time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if wait > 0 {
+		m.waits[group]++
+		m.waitTime[group] += wait.Seconds()
+	}
+	if errors.Is(err, ErrRateLimited) {
+		m.rejections[group]++
+	}
+}
+
+// ObserveTokenRefresh counts the token refresh
+func (m *PrometheusMetrics) ObserveTokenRefresh(latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.refreshes++
+	if err != nil {
+		m.refreshFailures++
+	}
+	m.refreshLatency.observe(latency.Seconds())
+}
+
+// ObserveCertificateExpiry records the expiration time of the certificate of the client
+func (m *PrometheusMetrics) ObserveCertificateExpiry(clientID string, expiresAt time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.certificates[clientID] = expiresAt
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format
+func (m *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	m.mu.Lock()
+	m.write(bw)
+	m.mu.Unlock()
+
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// write must be called with the lock held
+func (m *PrometheusMetrics) write(w *bufio.Writer) {
+	header(w, "interbank_requests_total", "counter", "Total de requisições à API do Inter por serviço, método e classe de status.")
+	for _, key := range sortedKeys(m.requests, func(k [3]string) string { return strings.Join(k[:], "\x00") }) {
+		sample(w, "interbank_requests_total", labels("service", key[0], "method", key[1], "status_class", key[2]), float64(m.requests[key]))
+	}
+
+	header(w, "interbank_request_duration_seconds", "histogram", "Latência das requisições à API do Inter.")
+	for _, key := range sortedKeys(m.latencies, func(k [2]string) string { return strings.Join(k[:], "\x00") }) {
+		m.latencies[key].write(w, "interbank_request_duration_seconds", "service", key[0], "method", key[1])
+	}
+
+	header(w, "interbank_retries_total", "counter", "Total de retentativas por serviço e método.")
+	for _, key := range sortedKeys(m.retries, func(k [2]string) string { return strings.Join(k[:], "\x00") }) {
+		sample(w, "interbank_retries_total", labels("service", key[0], "method", key[1]), float64(m.retries[key]))
+	}
+
+	header(w, "interbank_rate_limit_waits_total", "counter", "Total de esperas pelo rate limiter do cliente por grupo de endpoints.")
+	for _, group := range sortedKeys(m.waits, identity) {
+		sample(w, "interbank_rate_limit_waits_total", labels("group", group), float64(m.waits[group]))
+	}
+
+	header(w, "interbank_rate_limit_wait_seconds_total", "counter", "Tempo total de espera pelo rate limiter do cliente por grupo de endpoints.")
+	for _, group := range sortedKeys(m.waitTime, identity) {
+		sample(w, "interbank_rate_limit_wait_seconds_total", labels("group", group), m.waitTime[group])
+	}
+
+	header(w, "interbank_rate_limit_rejections_total", "counter", "Total de chamadas rejeitadas pelo rate limiter do cliente por grupo de endpoints.")
+	for _, group := range sortedKeys(m.rejections, identity) {
+		sample(w, "interbank_rate_limit_rejections_total", labels("group", group), float64(m.rejections[group]))
+	}
+
+	header(w, "interbank_token_refreshes_total", "counter", "Total de renovações do token.")
+	sample(w, "interbank_token_refreshes_total", "", float64(m.refreshes))
+
+	header(w, "interbank_token_refresh_failures_total", "counter", "Total de falhas na renovação do token.")
+	sample(w, "interbank_token_refresh_failures_total", "", float64(m.refreshFailures))
+
+	header(w, "interbank_token_refresh_duration_seconds", "histogram", "Duração das renovações do token.")
+	m.refreshLatency.write(w, "interbank_token_refresh_duration_seconds")
+
+	header(w, "interbank_tls_certificate_expiry_days", "gauge", "Dias até a expiração do certificado mTLS.")
+	for _, clientID := range sortedKeys(m.certificates, identity) {
+		days := time.Until(m.certificates[clientID]).Hours() / 24
+		sample(w, "interbank_tls_certificate_expiry_days", labels("client_id", clientID), days)
+	}
+}
+
+// histogram is a cumulative histogram with fixed buckets
+type histogram struct {
+	bounds []float64
+	counts []uint64 // Contagem por bucket (não cumulativa)
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+}
+
+func (h *histogram) observe(v float64) {
+	h.sum += v
+	h.count++
+	if i := sort.SearchFloat64s(h.bounds, v); i < len(h.bounds) {
+		h.counts[i]++
+	}
+}
+
+// write writes the buckets, the sum and the count of the histogram with the given label pairs
+func (h *histogram) write(w *bufio.Writer, name string, pairs ...string) {
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		sample(w, name+"_bucket", labels(append(pairs, "le", formatFloat(bound))...), float64(cumulative))
+	}
+	sample(w, name+"_bucket", labels(append(pairs, "le", "+Inf")...), float64(h.count))
+	sample(w, name+"_sum", labels(pairs...), h.sum)
+	sample(w, name+"_count", labels(pairs...), float64(h.count))
+}
+
+func header(w *bufio.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func sample(w *bufio.Writer, name, labels string, value float64) {
+	fmt.Fprintf(w, "%s%s %s\n", name, labels, formatFloat(value))
+}
+
+// labels formats the label pairs (name, value, name, value...)
+func labels(pairs ...string) string {
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(pairs[i])
+		b.WriteString(`="`)
+		b.WriteString(labelEscaper.Replace(pairs[i+1]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// sortedKeys returns the keys of the map sorted by the given string form
+func sortedKeys[K comparable, V any](m map[K]V, str func(K) string) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return str(keys[i]) < str(keys[j]) })
+	return keys
+}
+
+func identity(s string) string { return s }
+
+// countWriter counts the bytes written
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,287 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/raniellyferreira/interbank-go/erros"
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+// ErrRateLimited é retornado quando a chamada excederia o limite de requisições do grupo
+// e o contexto não permite esperar (fail fast ou prazo insuficiente); veja erros.IsRateLimited
+var ErrRateLimited = erros.ErrRateLimited
+
+// RateLimit é o limite de requisições de um grupo de endpoints (token bucket)
+type RateLimit struct {
+	Requests int           // Requisições permitidas por período
+	Per      time.Duration // Período
+	Burst    int           // Rajada máxima; quando zero, igual a Requests
+}
+
+// burst returns the bucket capacity
+func (l RateLimit) burst() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Requests)
+}
+
+// interval returns the time needed to refill one request
+func (l RateLimit) interval() time.Duration {
+	return l.Per / time.Duration(l.Requests)
+}
+
+// valid returns true if the limit can be enforced
+func (l RateLimit) valid() bool {
+	return l.Requests > 0 && l.Per > 0
+}
+
+// DefaultRateLimits são os limites padrão por grupo de endpoints, identificados pelo prefixo do caminho.
+// São valores conservadores escolhidos pela biblioteca, não as cotas oficiais do Inter (que variam por
+// conta e aplicação): confira as cotas da sua aplicação no Internet Banking e ajuste com SetRateLimit.
+var DefaultRateLimits = map[string]RateLimit{
+	"oauth/v2":              {Requests: 5, Per: time.Minute},
+	"banking/v2/saldo":      {Requests: 10, Per: time.Minute},
+	"banking/v2/extrato":    {Requests: 10, Per: time.Minute},
+	"banking/v2/webhooks":   {Requests: 60, Per: time.Minute},
+	"pix/v2/cob":            {Requests: 120, Per: time.Minute},
+	"pix/v2/cobv":           {Requests: 120, Per: time.Minute},
+	"pix/v2/loc":            {Requests: 120, Per: time.Minute},
+	"pix/v2/lotecobv":       {Requests: 60, Per: time.Minute},
+	"pix/v2/pix":            {Requests: 120, Per: time.Minute},
+	"pix/v2/webhook":        {Requests: 60, Per: time.Minute},
+	"cobranca/v3/cobrancas": {Requests: 120, Per: time.Minute},
+}
+
+// RateLimitBudget é o estado atual do limite de um grupo
+type RateLimitBudget struct {
+	Group     string        // Grupo de endpoints (prefixo do caminho)
+	Limit     RateLimit     // Limite configurado
+	Available float64       // Requisições disponíveis agora
+	Wait      time.Duration // Espera até a próxima requisição disponível (zero se Available >= 1)
+}
+
+type rateLimitFailFastKey struct{}
+
+// WithRateLimitFailFast returns a context whose calls fail with ErrRateLimited instead of waiting for the rate limiter
+func WithRateLimitFailFast(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rateLimitFailFastKey{}, true)
+}
+
+// bucket is the token bucket of a group
+type bucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens accumulated since the last update
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.limit.burst(), b.tokens+float64(elapsed)/float64(b.limit.interval()))
+	}
+	b.last = now
+}
+
+// wait returns the time until one token is available
+func (b *bucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) * float64(b.limit.interval()))
+}
+
+// RateLimiter limita as requisições por grupo de endpoints usando token buckets.
+// Cada caminho pertence ao grupo de maior prefixo que o contém; caminhos sem grupo não são limitados.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewRateLimiter creates a new rate limiter with the given limits per group
+func NewRateLimiter(limits map[string]RateLimit) *RateLimiter {
+	l := &RateLimiter{buckets: make(map[string]*bucket, len(limits))}
+	for group, limit := range limits {
+		l.SetLimit(group, limit)
+	}
+	return l
+}
+
+// SetLimit sets (or removes, when the limit is zero) the limit of a group
+func (l *RateLimiter) SetLimit(group string, limit RateLimit) *RateLimiter {
+	group = strings.Trim(group, "/")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !limit.valid() {
+		delete(l.buckets, group)
+		return l
+	}
+
+	l.buckets[group] = &bucket{
+		limit:  limit,
+		tokens: limit.burst(),
+		last:   time.Now(),
+	}
+	return l
+}
+
+// Group returns the group of the path, or an empty string if it is not limited
+func (l *RateLimiter) Group(path string) string {
+	path = groupPath(path)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	group := ""
+	for prefix := range l.buckets {
+		if len(prefix) > len(group) && (path == prefix || strings.HasPrefix(path, prefix+"/")) {
+			group = prefix
+		}
+	}
+	return group
+}
+
+// Wait takes one request from the group of the path, waiting for the budget to refill.
+// Returns ErrRateLimited without waiting if the context is fail fast (see WithRateLimitFailFast)
+// or if its deadline expires before the next request is available.
+func (l *RateLimiter) Wait(ctx context.Context, path string) error {
+	_, _, err := l.wait(ctx, path)
+	return err
+}
+
+// wait implements Wait, returning the group of the path and the time waited
+func (l *RateLimiter) wait(ctx context.Context, path string) (string, time.Duration, error) {
+	group := l.Group(path)
+	if group == "" {
+		return "", 0, nil
+	}
+
+	var waited time.Duration
+	failFast, _ := ctx.Value(rateLimitFailFastKey{}).(bool)
+	for {
+		wait, ok := l.take(group)
+		if !ok || wait == 0 {
+			return group, waited, nil
+		}
+
+		if failFast {
+			return group, waited, fmt.Errorf("%w: %s (next request in %s)", ErrRateLimited, group, wait.Round(time.Millisecond))
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return group, waited, fmt.Errorf("%w: %s (next request in %s, after the context deadline)", ErrRateLimited, group, wait.Round(time.Millisecond))
+		}
+
+		start := time.Now()
+		err := interutils.Sleep(ctx, wait)
+		waited += time.Since(start)
+		if err != nil {
+			return group, waited, err
+		}
+	}
+}
+
+// Budget returns the current budget of a group
+func (l *RateLimiter) Budget(group string) (RateLimitBudget, bool) {
+	group = strings.Trim(group, "/")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[group]
+	if !ok {
+		return RateLimitBudget{}, false
+	}
+	return l.budget(group, b), true
+}
+
+// Budgets returns the current budget of all groups, sorted by group
+func (l *RateLimiter) Budgets() []RateLimitBudget {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	budgets := make([]RateLimitBudget, 0, len(l.buckets))
+	for group, b := range l.buckets {
+		budgets = append(budgets, l.budget(group, b))
+	}
+	sort.Slice(budgets, func(i, j int) bool { return budgets[i].Group < budgets[j].Group })
+
+	return budgets
+}
+
+// take consumes one token of the group, or returns the time until one is available
+func (l *RateLimiter) take(group string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[group]
+	if !ok {
+		return 0, false
+	}
+
+	b.refill(time.Now())
+	if wait := b.wait(); wait > 0 {
+		return wait, true
+	}
+
+	b.tokens--
+	return 0, true
+}
+
+// budget must be called with the lock held
+func (l *RateLimiter) budget(group string, b *bucket) RateLimitBudget {
+	b.refill(time.Now())
+	return RateLimitBudget{
+		Group:     group,
+		Limit:     b.limit,
+		Available: b.tokens,
+		Wait:      b.wait(),
+	}
+}
+
+// groupPath returns the path of a relative or absolute URL, without the leading slash
+func groupPath(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		rawURL = u.Path
+	}
+	return strings.Trim(rawURL, "/")
+}
+
+// SetRateLimiter sets the rate limiter of the backend (nil disables the rate limiting)
+func (c *BackendImplement) SetRateLimiter(limiter *RateLimiter) *BackendImplement {
+	c.limiter = limiter
+	return c
+}
+
+// RateLimiter returns the rate limiter of the backend (nil if disabled)
+func (c *BackendImplement) RateLimiter() *RateLimiter {
+	return c.limiter
+}
+
+// SetRateLimit sets the limit of a group of endpoints, identified by the path prefix (e.g. "pix/v2/cob")
+func (c *BackendImplement) SetRateLimit(group string, limit RateLimit) *BackendImplement {
+	if c.limiter == nil {
+		c.limiter = NewRateLimiter(nil)
+	}
+	c.limiter.SetLimit(group, limit)
+	return c
+}
+
+// waitRateLimit waits for the budget of the path group, if the rate limiter is enabled
+func (c *BackendImplement) waitRateLimit(ctx context.Context, path string) error {
+	if c.limiter == nil {
+		return nil
+	}
+
+	group, waited, err := c.limiter.wait(ctx, path)
+	c.observeRateLimit(group, waited, err)
+	return err
+}
@@ -0,0 +1,110 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/erros"
+)
+
+func TestRateLimiterRefill(t *testing.T) {
+	// Uma requisição a cada 50ms, com rajada de 2
+	l := NewRateLimiter(map[string]RateLimit{"pix/v2/cob": {Requests: 2, Per: 100 * time.Millisecond}})
+	ctx := WithRateLimitFailFast(context.Background())
+
+	for i := 0; i < 2; i++ {
+		if err := l.Wait(ctx, "/pix/v2/cob/txid"); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+
+	if err := l.Wait(ctx, "pix/v2/cob"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Wait() error = %v, want ErrRateLimited", err)
+	}
+
+	budget, ok := l.Budget("pix/v2/cob")
+	if !ok || budget.Available >= 1 || budget.Wait <= 0 || budget.Wait > 50*time.Millisecond {
+		t.Fatalf("Budget() = %+v, %v", budget, ok)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if err := l.Wait(ctx, "pix/v2/cob"); err != nil {
+		t.Fatalf("Wait() after refill: %v", err)
+	}
+
+	// Sem fail fast, espera a reposição
+	start := time.Now()
+	if err := l.Wait(context.Background(), "pix/v2/cob"); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Wait() returned after %s, want it to wait for the refill", elapsed)
+	}
+
+	// O bucket nunca passa da rajada
+	time.Sleep(300 * time.Millisecond)
+	if budget, _ := l.Budget("pix/v2/cob"); budget.Available != 2 {
+		t.Errorf("Available = %v, want 2", budget.Available)
+	}
+}
+
+func TestRateLimiterDeadline(t *testing.T) {
+	l := NewRateLimiter(map[string]RateLimit{"pix/v2/cob": {Requests: 1, Per: time.Minute}})
+	if err := l.Wait(context.Background(), "pix/v2/cob"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := l.Wait(ctx, "pix/v2/cob"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Wait() error = %v, want ErrRateLimited", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Wait() took %s, want it to fail without waiting", elapsed)
+	}
+}
+
+func TestRateLimiterGroups(t *testing.T) {
+	l := NewRateLimiter(map[string]RateLimit{
+		"pix/v2/cob":  {Requests: 1, Per: time.Minute},
+		"pix/v2/cobv": {Requests: 1, Per: time.Minute},
+	})
+
+	tests := map[string]string{
+		"pix/v2/cob":                  "pix/v2/cob",
+		"/pix/v2/cob/txid":            "pix/v2/cob",
+		"https://host/pix/v2/cobv/tx": "pix/v2/cobv",
+		"pix/v2/cobranca":             "",
+		"banking/v2/saldo":            "",
+	}
+	for path, want := range tests {
+		if got := l.Group(path); got != want {
+			t.Errorf("Group(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRateLimitFailFast(t *testing.T) {
+	handler, calls := failFirst(0, http.StatusOK, nil)
+	b := newTestBackend(t, handler)
+	b.SetRateLimiter(NewRateLimiter(map[string]RateLimit{"pix/v2/cob": {Requests: 1, Per: time.Minute}}))
+
+	ctx := WithRateLimitFailFast(context.Background())
+	if err := b.Do(ctx, &Call{Operation: "test", Method: resty.MethodGet, Endpoint: "pix/v2/cob"}); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+
+	err := b.Do(ctx, &Call{Operation: "test", Method: resty.MethodGet, Endpoint: "pix/v2/cob/{txid}", PathParams: map[string]string{"txid": "abc"}})
+	if !errors.Is(err, ErrRateLimited) || !erros.IsRateLimited(err) {
+		t.Fatalf("second call error = %v, want ErrRateLimited", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("requests sent = %d, want 1", got)
+	}
+}
@@ -0,0 +1,97 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted substitui os valores omitidos dos logs
+const Redacted = "[REDACTED]"
+
+// reDocumento matches CPF and CNPJ numbers, with or without punctuation
+var reDocumento = regexp.MustCompile(`\b(?:\d{3}\.?\d{3}\.?\d{3}-?\d{2}|\d{2}\.?\d{3}\.?\d{3}/?\d{4}-?\d{2})\b`)
+
+// sensitiveKey returns true if the field holds a secret, a token or a CPF/CNPJ
+func sensitiveKey(key string) bool {
+	key = strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+
+	switch key {
+	case "cpf", "cnpj", "cpfcnpj", "authorization":
+		return true
+	case "tokentype":
+		return false
+	}
+
+	for _, part := range []string{"token", "secret", "senha", "password"} {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactBody returns a copy of the body without secrets, tokens and CPF/CNPJ numbers.
+// JSON bodies have the sensitive fields replaced; in any text the CPF/CNPJ numbers are replaced.
+func RedactBody(body []byte) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return body
+	}
+
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		// Preserva os números como no original
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.UseNumber()
+
+		var v any
+		if err := dec.Decode(&v); err == nil {
+			if redacted, err := json.Marshal(redactValue(v)); err == nil {
+				return redacted
+			}
+		}
+	}
+
+	return reDocumento.ReplaceAll(body, []byte(Redacted))
+}
+
+// redactValue replaces the sensitive fields of a decoded JSON value
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if sensitiveKey(key) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+		return v
+	case string:
+		return reDocumento.ReplaceAllString(v, Redacted)
+	}
+	return v
+}
+
+// redactForm returns a copy of the form values without the sensitive fields
+func redactForm(form url.Values) url.Values {
+	redacted := make(url.Values, len(form))
+	for key, values := range form {
+		for _, value := range values {
+			if sensitiveKey(key) {
+				value = Redacted
+			} else {
+				value = reDocumento.ReplaceAllString(value, Redacted)
+			}
+			redacted.Add(key, value)
+		}
+	}
+	return redacted
+}
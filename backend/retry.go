@@ -0,0 +1,229 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+// RetryPolicy define como as requisições com falhas transitórias são repetidas.
+// Falhas transitórias são erros de transporte e as respostas 408, 429, 502, 503 e 504.
+type RetryPolicy struct {
+	MaxAttempts   int           // Número máximo de tentativas, incluindo a primeira (1 desativa as retentativas)
+	WaitTime      time.Duration // Espera inicial do backoff exponencial (com jitter)
+	MaxWaitTime   time.Duration // Espera máxima entre tentativas, inclusive a indicada pelo Retry-After
+	NonIdempotent bool          // Repete também as requisições não declaradas idempotentes (POST, PATCH, PUT e DELETE)
+
+	nonIdempotentSet bool // NonIdempotent foi definido explicitamente na chamada
+}
+
+// DefaultRetryPolicy é a política usada quando nenhuma outra é configurada
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	WaitTime:    500 * time.Millisecond,
+	MaxWaitTime: 30 * time.Second,
+}
+
+// merge returns the policy with the zero fields taken from base.
+// NonIdempotent is taken from base unless it was set explicitly.
+func (p RetryPolicy) merge(base RetryPolicy) RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = base.MaxAttempts
+	}
+	if p.WaitTime <= 0 {
+		p.WaitTime = base.WaitTime
+	}
+	if p.MaxWaitTime <= 0 {
+		p.MaxWaitTime = base.MaxWaitTime
+	}
+	if !p.nonIdempotentSet {
+		p.NonIdempotent = base.NonIdempotent
+	}
+	return p
+}
+
+type retryPolicyKey struct{}
+
+// WithRetryPolicy returns a context that overrides the retry policy of the calls made with it.
+// Zero fields keep the value configured in the backend; NonIdempotent is applied as given
+// (use WithRetryNonIdempotent or WithoutRetryNonIdempotent to change only it).
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	policy.nonIdempotentSet = true
+	return withRetryPolicy(ctx, policy)
+}
+
+func withRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// WithMaxAttempts returns a context that overrides the maximum number of attempts of the calls made with it
+func WithMaxAttempts(ctx context.Context, attempts int) context.Context {
+	policy := retryPolicyFromContext(ctx)
+	policy.MaxAttempts = attempts
+	return withRetryPolicy(ctx, policy)
+}
+
+// WithRetryNonIdempotent returns a context that allows retrying the calls not declared idempotent.
+// Use only when the operation is known to be safe to repeat.
+func WithRetryNonIdempotent(ctx context.Context) context.Context {
+	policy := retryPolicyFromContext(ctx)
+	policy.NonIdempotent, policy.nonIdempotentSet = true, true
+	return withRetryPolicy(ctx, policy)
+}
+
+// WithoutRetryNonIdempotent returns a context that never retries the calls not declared idempotent,
+// even if the backend policy allows it
+func WithoutRetryNonIdempotent(ctx context.Context) context.Context {
+	policy := retryPolicyFromContext(ctx)
+	policy.NonIdempotent, policy.nonIdempotentSet = false, true
+	return withRetryPolicy(ctx, policy)
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent returns a context that declares the calls made with it safe to repeat
+// (e.g. a PUT that creates a resource with a client-chosen id), so they are retried like a GET
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func idempotentFromContext(ctx context.Context) bool {
+	idempotent, _ := ctx.Value(idempotentKey{}).(bool)
+	return idempotent
+}
+
+func retryPolicyFromContext(ctx context.Context) RetryPolicy {
+	policy, _ := ctx.Value(retryPolicyKey{}).(RetryPolicy)
+	return policy
+}
+
+// SetRetryPolicy sets the retry policy of the backend
+func (c *BackendImplement) SetRetryPolicy(policy RetryPolicy) *BackendImplement {
+	policy.nonIdempotentSet = true
+	c.retry = policy.merge(DefaultRetryPolicy)
+	return c
+}
+
+// GetRetryPolicy returns the retry policy of the backend
+func (c *BackendImplement) GetRetryPolicy() RetryPolicy {
+	return c.retry
+}
+
+// Execute sends the request, repeating it with exponential backoff and jitter while it fails with
+// a transient error, according to the retry policy of the backend and of the request context.
+// Only GET, HEAD, OPTIONS and the calls declared idempotent (see WithIdempotent) are retried,
+// unless the policy allows retrying non-idempotent calls.
+// It is the last step of the middleware chain (see Do); the token is set by the Authenticate middleware.
+func (c *BackendImplement) Execute(req *resty.Request, method, url string) (*resty.Response, error) {
+	resp, attempts, err := c.execute(req, method, url)
+	c.traceCall(req, method, url, attempts, resp, err)
+	return resp, err
+}
+
+// execute runs the attempts of Execute, returning the number of attempts made
+func (c *BackendImplement) execute(req *resty.Request, method, url string) (*resty.Response, int, error) {
+	ctx := req.Context()
+	policy := retryPolicyFromContext(ctx).merge(c.retry)
+	retryable := policy.NonIdempotent || idempotentFromContext(ctx) || idempotent(method)
+
+	applyAccount(req)
+
+	for attempt := 1; ; attempt++ {
+		if err := c.waitRateLimit(ctx, url); err != nil {
+			// Resposta sem status com a mensagem do erro, como nas falhas de transporte
+			return (&resty.Response{Request: req}).SetBody([]byte(err.Error())), attempt, err
+		}
+
+		start := time.Now()
+		resp, err := req.Execute(method, url)
+		latency := time.Since(start)
+		c.logAttempt(req, method, url, attempt, resp, err, latency)
+		c.observeRequest(OperationFromContext(ctx), statusCode(resp), err, latency)
+
+		if !retryable || attempt >= policy.MaxAttempts || ctx.Err() != nil || !transient(resp, err) {
+			return resp, attempt, err
+		}
+
+		wait := interutils.Backoff(attempt-1, policy.WaitTime, policy.MaxWaitTime)
+		if retryAfter, ok := parseRetryAfter(resp); ok {
+			// O servidor pediu para esperar mais do que o permitido: desiste e devolve a resposta
+			if retryAfter > policy.MaxWaitTime {
+				return resp, attempt, err
+			}
+			wait = retryAfter
+		}
+
+		// Não espera se a próxima tentativa não cabe no prazo do contexto
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return resp, attempt, err
+		}
+
+		if interutils.Sleep(ctx, wait) != nil {
+			return resp, attempt, err
+		}
+
+		c.observeRetry(OperationFromContext(ctx))
+	}
+}
+
+// idempotent returns true if the HTTP method is safe to repeat whatever the operation.
+// PUT and DELETE are idempotent only when the operation declares it (see WithIdempotent).
+func idempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case resty.MethodGet, resty.MethodHead, resty.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// transient returns true if the request failed with an error that may succeed on a new attempt
+func transient(resp *resty.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	if resp == nil {
+		return false
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter returns the wait time of the Retry-After header (seconds or HTTP date)
+func parseRetryAfter(resp *resty.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(resp.Header().Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,191 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
+	"github.com/raniellyferreira/interbank-go/erros"
+)
+
+// newTestBackend returns a backend pointing to a test server that issues tokens and
+// sends the other requests to handler. Rate limiting is disabled and retries wait 1ms.
+func newTestBackend(t *testing.T, handler http.HandlerFunc) *BackendImplement {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/v2/token" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token":"token","token_type":"Bearer","expires_in":3600}`)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	b := NewBackendWithCredentials(auth.NewCredentials("id", "secret")).
+		SetURL(srv.URL).
+		SetRateLimiter(nil).
+		SetRetryPolicy(RetryPolicy{MaxAttempts: 3, WaitTime: time.Millisecond, MaxWaitTime: time.Second})
+	t.Cleanup(func() { b.Close() })
+
+	return b
+}
+
+// failFirst responds with status to the first n requests and 200 to the others
+func failFirst(n int32, status int, header http.Header) (http.HandlerFunc, *atomic.Int32) {
+	var calls atomic.Int32
+	return func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= n {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{}`)
+	}, &calls
+}
+
+func TestRetryTransientGet(t *testing.T) {
+	handler, calls := failFirst(1, http.StatusServiceUnavailable, nil)
+	b := newTestBackend(t, handler)
+
+	err := b.Do(context.Background(), &Call{Operation: "test", Method: resty.MethodGet, Endpoint: "pix/v2/cob"})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestRetryNonTransient(t *testing.T) {
+	handler, calls := failFirst(1, http.StatusBadRequest, nil)
+	b := newTestBackend(t, handler)
+
+	err := b.Do(context.Background(), &Call{Operation: "test", Method: resty.MethodGet, Endpoint: "pix/v2/cob"})
+	if !erros.IsValidation(err) {
+		t.Fatalf("Do() error = %v, want a 400", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        func(context.Context) context.Context
+		method     string
+		idempotent bool
+		want       int32
+	}{
+		{"post not retried", nil, resty.MethodPost, false, 1},
+		{"patch not retried", nil, resty.MethodPatch, false, 1},
+		{"put not retried", nil, resty.MethodPut, false, 1},
+		{"put declared idempotent", nil, resty.MethodPut, true, 2},
+		{"delete declared idempotent by context", WithIdempotent, resty.MethodDelete, false, 2},
+		{"post retried when allowed", WithRetryNonIdempotent, resty.MethodPost, false, 2},
+		{"get not retried with max attempts 1", func(ctx context.Context) context.Context { return WithMaxAttempts(ctx, 1) }, resty.MethodGet, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, calls := failFirst(1, http.StatusServiceUnavailable, nil)
+			b := newTestBackend(t, handler)
+
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx(ctx)
+			}
+
+			err := b.Do(ctx, &Call{Operation: "test", Method: tt.method, Endpoint: "pix/v2/cob", Idempotent: tt.idempotent})
+			if got := calls.Load(); got != tt.want {
+				t.Errorf("attempts = %d, want %d", got, tt.want)
+			}
+			if tt.want == 1 && tt.method != resty.MethodGet && !erros.IsServerError(err) {
+				t.Errorf("Do() error = %v, want the 503", err)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyOverride(t *testing.T) {
+	handler, calls := failFirst(1, http.StatusServiceUnavailable, nil)
+	b := newTestBackend(t, handler)
+	b.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, WaitTime: time.Millisecond, NonIdempotent: true})
+
+	// A política da chamada desativa as retentativas não idempotentes permitidas pelo backend
+	ctx := WithoutRetryNonIdempotent(context.Background())
+	if err := b.Do(ctx, &Call{Operation: "test", Method: resty.MethodPost, Endpoint: "pix/v2/cob"}); !erros.IsServerError(err) {
+		t.Fatalf("Do() error = %v, want the 503", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		attempts   int32
+		minWait    time.Duration
+	}{
+		{"seconds", "1", 2, time.Second},
+		{"seconds longer than max wait", "120", 1, 0},
+		{"http date longer than max wait", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), 1, 0},
+		{"http date in the past", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, calls := failFirst(1, http.StatusTooManyRequests, http.Header{"Retry-After": {tt.retryAfter}})
+			b := newTestBackend(t, handler)
+			b.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, WaitTime: time.Millisecond, MaxWaitTime: 5 * time.Second})
+
+			start := time.Now()
+			err := b.Do(context.Background(), &Call{Operation: "test", Method: resty.MethodGet, Endpoint: "pix/v2/cob"})
+			elapsed := time.Since(start)
+
+			if got := calls.Load(); got != tt.attempts {
+				t.Errorf("attempts = %d, want %d", got, tt.attempts)
+			}
+			if tt.attempts == 1 && !erros.IsRateLimited(err) {
+				t.Errorf("Do() error = %v, want the 429", err)
+			}
+			if elapsed < tt.minWait {
+				t.Errorf("waited %s, want at least %s", elapsed, tt.minWait)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	header := func(value string) *resty.Response {
+		return &resty.Response{RawResponse: &http.Response{Header: http.Header{"Retry-After": {value}}}}
+	}
+
+	if wait, ok := parseRetryAfter(header("3")); !ok || wait != 3*time.Second {
+		t.Errorf("parseRetryAfter(3) = %s, %v", wait, ok)
+	}
+
+	date := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(header(date)); !ok || wait < 28*time.Second || wait > 30*time.Second {
+		t.Errorf("parseRetryAfter(%s) = %s, %v", date, wait, ok)
+	}
+
+	for _, value := range []string{"", "-1", "soon"} {
+		if _, ok := parseRetryAfter(header(value)); ok {
+			t.Errorf("parseRetryAfter(%q) should be ignored", value)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+package backend
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"path"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
+	"github.com/raniellyferreira/interbank-go/erros"
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultTokenSkew é a antecedência, em relação à expiração informada pelo Inter, com que o token deixa de ser usado
+	DefaultTokenSkew = 180 * time.Second
+
+	// DefaultTokenJitter é a variação aleatória máxima da renovação em segundo plano, para que várias
+	// instâncias não renovem ao mesmo tempo
+	DefaultTokenJitter = 30 * time.Second
+
+	// tokenRenewalRetry é a espera máxima entre as tentativas de renovação em segundo plano
+	tokenRenewalRetry = 30 * time.Second
+)
+
+// tokenRenewal holds the background renewal settings
+type tokenRenewal struct {
+	mu      sync.Mutex
+	enabled bool
+	skew    time.Duration
+	jitter  time.Duration
+	closed  bool
+}
+
+// tokenState is the token of a set of scopes
+type tokenState struct {
+	key    string       // Conjunto de escopos separados por espaço ("" para os escopos das credenciais)
+	scopes []auth.Scope // Escopos solicitados (nil para os escopos das credenciais)
+	token  atomic.Pointer[auth.Token]
+	used   atomic.Bool // O token foi usado desde a última renovação
+	timer  *time.Timer // Renovação em segundo plano, protegida por renewal.mu
+}
+
+// SetTokenSkew sets how long before the expiration reported by Inter the token stops being used
+func (c *BackendImplement) SetTokenSkew(skew time.Duration) *BackendImplement {
+	c.renewal.mu.Lock()
+	c.renewal.skew = max(skew, 0)
+	c.renewal.mu.Unlock()
+	return c
+}
+
+// SetTokenJitter sets the maximum random anticipation of the background renewal
+func (c *BackendImplement) SetTokenJitter(jitter time.Duration) *BackendImplement {
+	c.renewal.mu.Lock()
+	c.renewal.jitter = max(jitter, 0)
+	c.renewal.mu.Unlock()
+	return c
+}
+
+// SetTokenRenewal enables or disables the background renewal of the token (enabled by default).
+// A token is renewed before it expires only if it was used since the last renewal, so an idle client
+// stops renewing after one period; Close stops the timers. When disabled, the token is renewed on
+// demand by the first call after it expires.
+func (c *BackendImplement) SetTokenRenewal(enabled bool) *BackendImplement {
+	c.renewal.mu.Lock()
+	c.renewal.enabled = enabled
+	if !enabled {
+		c.stopRenewals()
+	}
+	c.renewal.mu.Unlock()
+	return c
+}
+
+// SetScopedTokens sets whether a separate token is requested for the scopes declared by each service
+// method (e.g. extrato.read for ConsultarSaldo). Enabled by default; disable it to use one token with
+// all the scopes of the credentials, saving requests to oauth/v2/token (the most limited endpoint of
+// the API) at the cost of a token with more privileges than each call needs.
+func (c *BackendImplement) SetScopedTokens(enabled bool) *BackendImplement {
+	c.scoped.Store(enabled)
+	return c
+}
+
+// Close stops the background renewal of the tokens
+func (c *BackendImplement) Close() error {
+	c.renewal.mu.Lock()
+	c.renewal.closed = true
+	c.stopRenewals()
+	c.renewal.mu.Unlock()
+	return nil
+}
+
+// stopRenewals stops the renewal timers, must be called with renewal.mu held
+func (c *BackendImplement) stopRenewals() {
+	c.tokens.Range(func(_, value any) bool {
+		st := value.(*tokenState)
+		if st.timer != nil {
+			st.timer.Stop()
+			st.timer = nil
+		}
+		return true
+	})
+}
+
+// Token returns the current token of the scopes or requests a new one.
+// Scopes are ignored when scoped tokens are disabled (see SetScopedTokens); the token then
+// has the scopes of the credentials. A valid token is returned without locking, and concurrent
+// calls with an expired token share a single request.
+func (c *BackendImplement) Token(ctx context.Context, scopes ...auth.Scope) (*auth.Token, error) {
+	st := c.tokenState(scopes)
+	if token := st.token.Load(); token != nil && token.Valid() {
+		st.used.Store(true)
+		return token, nil
+	}
+
+	token, err := c.refreshToken(ctx, st)
+	if err != nil && OperationFromContext(ctx) != "" {
+		// Falha da chamada antes da requisição: registra no span da chamada
+		recordError(trace.SpanFromContext(ctx), err)
+	}
+	return token, err
+}
+
+// InvalidateToken discards the token with the given access token (e.g. after a 401),
+// so that the next call requests a new token
+func (c *BackendImplement) InvalidateToken(accessToken string) {
+	c.invalid.Store(accessToken)
+
+	c.tokens.Range(func(_, value any) bool {
+		st := value.(*tokenState)
+		if token := st.token.Load(); token != nil && token.GetAccessToken() == accessToken {
+			st.token.CompareAndSwap(token, nil)
+		}
+		return true
+	})
+}
+
+// tokenState returns the state of the set of scopes, creating it if needed
+func (c *BackendImplement) tokenState(scopes []auth.Scope) *tokenState {
+	if !c.scoped.Load() {
+		scopes = nil
+	}
+
+	if len(scopes) > 0 {
+		scopes = slices.Clone(scopes)
+		slices.Sort(scopes)
+		scopes = slices.Compact(scopes)
+	}
+
+	key := auth.JoinScopes(scopes)
+	if st, ok := c.tokens.Load(key); ok {
+		return st.(*tokenState)
+	}
+
+	st, _ := c.tokens.LoadOrStore(key, &tokenState{key: key, scopes: scopes})
+	return st.(*tokenState)
+}
+
+// refreshToken obtains a new token, sharing the in-flight refresh with concurrent callers.
+// The refresh is not canceled when the caller's context is, as other callers may be waiting for it.
+func (c *BackendImplement) refreshToken(ctx context.Context, st *tokenState) (*auth.Token, error) {
+	result := c.refresh.DoChan(st.key, func() (any, error) {
+		// Outra chamada pode ter renovado o token enquanto esta aguardava
+		if token := st.token.Load(); token != nil && token.Valid() {
+			return token, nil
+		}
+
+		token, err := c.obtainToken(context.WithoutCancel(ctx), st, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		c.useToken(st, token)
+		return token, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-result:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*auth.Token), nil
+	}
+}
+
+// obtainToken returns a token newer than current, taken from the token store when another instance
+// has already renewed it, or requested while holding the renewal lease of the store
+func (c *BackendImplement) obtainToken(ctx context.Context, st *tokenState, current *auth.Token) (token *auth.Token, err error) {
+	ctx, span := c.startSpan(ctx, "backend.RefreshToken", attribute.String("interbank.token.scopes", auth.JoinScopes(st.scopes)))
+	start := time.Now()
+	defer func() {
+		c.observeTokenRefresh(time.Since(start), err)
+		if err != nil {
+			recordError(span, err)
+		}
+		span.End()
+	}()
+
+	key := c.TokenKey(st.scopes...)
+	deadline := time.Now().Add(c.leaseTTL)
+
+	for attempt := 0; time.Now().Before(deadline); attempt++ {
+		if token := c.loadToken(ctx, key, current); token != nil {
+			return token, nil
+		}
+
+		release, acquired, err := c.store.Lease(ctx, key, c.leaseTTL)
+		if err != nil {
+			break
+		}
+
+		if acquired {
+			defer release()
+
+			// Outra instância pode ter renovado entre a consulta e o lease
+			if token := c.loadToken(ctx, key, current); token != nil {
+				return token, nil
+			}
+			return c.requestAndStoreToken(ctx, st, key)
+		}
+
+		// Outra instância está renovando: aguarda o novo token aparecer no store
+		if err := interutils.Sleep(ctx, interutils.Backoff(attempt, 100*time.Millisecond, 2*time.Second)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Store indisponível ou lease não liberado a tempo: renova sem o lease
+	return c.requestAndStoreToken(ctx, st, key)
+}
+
+// loadToken returns the stored token if it is valid, was not invalidated and is newer than current
+func (c *BackendImplement) loadToken(ctx context.Context, key string, current *auth.Token) *auth.Token {
+	token, err := c.store.Load(ctx, key)
+	if err != nil || token == nil || !token.Valid() {
+		return nil
+	}
+
+	if invalid, _ := c.invalid.Load().(string); invalid != "" && token.GetAccessToken() == invalid {
+		return nil
+	}
+
+	if current != nil && !token.GetExpiresAt().After(current.GetExpiresAt()) {
+		return nil
+	}
+
+	return token
+}
+
+// requestAndStoreToken requests a new token, sets its expiration and saves it in the token store
+func (c *BackendImplement) requestAndStoreToken(ctx context.Context, st *tokenState, key string) (*auth.Token, error) {
+	token, err := c.requestNewToken(ctx, st.scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	c.renewal.mu.Lock()
+	skew := c.renewal.skew
+	c.renewal.mu.Unlock()
+
+	// Tokens de vida curta usam metade da validade como margem
+	lifetime := time.Duration(token.ExpiresIn) * time.Second
+	if skew >= lifetime {
+		skew = lifetime / 2
+	}
+	token.SetExpiresAt(time.Now().Add(lifetime - skew))
+
+	// Falhas do store não impedem o uso do token nesta instância
+	_ = c.store.Store(ctx, key, token)
+
+	return token, nil
+}
+
+// useToken sets the token as the current one of the state and schedules its renewal
+func (c *BackendImplement) useToken(st *tokenState, token *auth.Token) {
+	st.token.Store(token)
+	st.used.Store(false)
+	c.scheduleRenewal(st, token, 0)
+}
+
+// TokenKey returns the key of the token in the token store, derived from the base URL, the credentials
+// and the scopes (the scopes of the credentials when none is given)
+func (c *BackendImplement) TokenKey(scopes ...auth.Scope) string {
+	scope := c.creds.GetScopesString()
+	if len(scopes) > 0 {
+		scope = auth.JoinScopes(scopes)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(c.GetURL()))
+	h.Write([]byte{0})
+	h.Write([]byte(c.creds.GetClientID()))
+	h.Write([]byte{0})
+	h.Write([]byte(scope))
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// SetTokenStore sets the store used to share the token between instances (default: in-memory, per backend)
+func (c *BackendImplement) SetTokenStore(store TokenStore) *BackendImplement {
+	if store == nil {
+		store = NewMemoryTokenStore()
+	}
+	c.store = store
+	return c
+}
+
+// SetTokenLeaseTTL sets the maximum duration of the renewal lease (default: DefaultTokenLeaseTTL)
+func (c *BackendImplement) SetTokenLeaseTTL(ttl time.Duration) *BackendImplement {
+	if ttl > 0 {
+		c.leaseTTL = ttl
+	}
+	return c
+}
+
+// scheduleRenewal schedules the background renewal of the token before it expires, with jitter.
+// Failed renewals are retried with backoff while the token is still valid.
+func (c *BackendImplement) scheduleRenewal(st *tokenState, token *auth.Token, attempt int) {
+	c.renewal.mu.Lock()
+	defer c.renewal.mu.Unlock()
+
+	if !c.renewal.enabled || c.renewal.closed {
+		return
+	}
+
+	var wait time.Duration
+	if attempt == 0 {
+		wait = time.Until(token.GetExpiresAt())
+		if wait <= 0 {
+			return
+		}
+		if jitter := min(c.renewal.jitter, wait/2); jitter > 0 {
+			wait -= rand.N(jitter)
+		}
+	} else {
+		wait = interutils.Backoff(attempt-1, time.Second, tokenRenewalRetry)
+		if time.Now().Add(wait).After(token.GetExpiresAt()) {
+			// O token expira antes da próxima tentativa: a próxima chamada renova sob demanda
+			return
+		}
+	}
+
+	if st.timer != nil {
+		st.timer.Stop()
+	}
+	st.timer = time.AfterFunc(wait, func() {
+		c.renew(st, token, attempt)
+	})
+}
+
+// renew replaces the token in the background, if it is still the current one and was used since
+// the last renewal; an unused token is left to expire and renewed on demand by the next call
+func (c *BackendImplement) renew(st *tokenState, token *auth.Token, attempt int) {
+	if st.token.Load() != token || !st.used.Load() {
+		return
+	}
+
+	// Força a renovação: o token atual ainda é válido, então não passa por Token()
+	_, err, _ := c.refresh.Do(st.key, func() (any, error) {
+		if current := st.token.Load(); current != token && current != nil && current.Valid() {
+			return current, nil
+		}
+
+		renewed, err := c.obtainToken(context.Background(), st, token)
+		if err != nil {
+			return nil, err
+		}
+
+		c.useToken(st, renewed)
+		return renewed, nil
+	})
+	if err != nil {
+		c.scheduleRenewal(st, token, attempt+1)
+	}
+}
+
+// requestNewToken requests a new token with the scopes (the scopes of the credentials when none is given)
+func (c *BackendImplement) requestNewToken(ctx context.Context, scopes []auth.Scope) (*auth.Token, error) {
+	if err := c.waitRateLimit(ctx, oauthEndpoint); err != nil {
+		return nil, err
+	}
+
+	req := c.Req().
+		SetContext(ctx).
+		SetResult(&auth.Token{}).
+		SetFormData(c.creds.BuildAuthFormData(scopes...))
+
+	// Send the request
+	endpoint := path.Join(oauthEndpoint, "token")
+	start := time.Now()
+	resp, err := req.Post(endpoint)
+	c.logAttempt(req, resty.MethodPost, endpoint, 1, resp, err, time.Since(start))
+	if err != nil {
+		return nil, erros.NewTransportError(err)
+	}
+
+	// Check for errors
+	if resp.IsError() {
+		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+	}
+
+	token, ok := resp.Result().(*auth.Token)
+	if !ok {
+		return nil, erros.NewErrorWithStatus(resp.StatusCode(), fmt.Sprintf("invalid response type: %T", resp.Result()))
+	}
+
+	return token, nil
+}
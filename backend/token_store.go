@@ -0,0 +1,261 @@
+package backend
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/raniellyferreira/interbank-go/auth"
+)
+
+// DefaultTokenLeaseTTL é a duração máxima do lease de renovação do token, após a qual outra instância pode assumi-lo
+const DefaultTokenLeaseTTL = 30 * time.Second
+
+// TokenStore armazena os tokens compartilhados entre instâncias (réplicas ou processos) que usam as
+// mesmas credenciais. O lease garante que apenas uma instância renove o token de cada chave por vez.
+type TokenStore interface {
+	// Load returns the stored token of the key, or nil if there is none
+	Load(ctx context.Context, key string) (*auth.Token, error)
+
+	// Store saves the token of the key
+	Store(ctx context.Context, key string, token *auth.Token) error
+
+	// Lease tries to acquire the renewal lease of the key for the given duration.
+	// Returns false if another instance holds it; release must be called after the renewal.
+	Lease(ctx context.Context, key string, ttl time.Duration) (release func(), acquired bool, err error)
+}
+
+// MemoryTokenStore é um TokenStore em memória, compartilhado apenas entre os clientes do mesmo processo
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]*auth.Token
+	leases map[string]time.Time
+}
+
+// NewMemoryTokenStore creates a new in-memory token store
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: make(map[string]*auth.Token),
+		leases: make(map[string]time.Time),
+	}
+}
+
+// Load returns the stored token of the key, or nil if there is none
+func (s *MemoryTokenStore) Load(ctx context.Context, key string) (*auth.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tokens[key], nil
+}
+
+// Store saves the token of the key
+func (s *MemoryTokenStore) Store(ctx context.Context, key string, token *auth.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[key] = token
+	return nil
+}
+
+// Lease tries to acquire the renewal lease of the key
+func (s *MemoryTokenStore) Lease(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if expiresAt, ok := s.leases[key]; ok && expiresAt.After(now) {
+		return nil, false, nil
+	}
+
+	expiresAt := now.Add(ttl)
+	s.leases[key] = expiresAt
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.leases[key] == expiresAt {
+			delete(s.leases, key)
+		}
+	}, true, nil
+}
+
+// FileTokenStore é um TokenStore em arquivos, para instâncias que compartilham um diretório
+// (volume compartilhado ou réplicas no mesmo host). Cada chave usa o arquivo <key>.json, gravado
+// de forma atômica, e o lease é o arquivo <key>.lock, criado de forma exclusiva.
+type FileTokenStore struct {
+	dir string
+}
+
+// NewFileTokenStore creates a file token store in the directory, creating it if needed
+func NewFileTokenStore(dir string) (*FileTokenStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileTokenStore{dir: dir}, nil
+}
+
+// fileLease is the content of a lease file
+type fileLease struct {
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Load returns the stored token of the key, or nil if there is none
+func (s *FileTokenStore) Load(ctx context.Context, key string) (*auth.Token, error) {
+	data, err := os.ReadFile(s.path(key, ".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token := &auth.Token{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Store saves the token of the key, replacing the file atomically
+func (s *FileTokenStore) Store(ctx context.Context, key string, token *auth.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return s.writeFile(s.path(key, ".json"), data)
+}
+
+// Lease tries to acquire the renewal lease of the key by creating the lock file.
+// An expired lock file left by a crashed instance is taken over.
+func (s *FileTokenStore) Lease(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	lock := s.path(key, ".lock")
+	lease := fileLease{Owner: randomID(), ExpiresAt: time.Now().Add(ttl)}
+
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for range 2 {
+		f, err := os.OpenFile(lock, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err == nil {
+			_, err = f.Write(data)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(lock)
+				return nil, false, err
+			}
+			return func() { s.release(lock, lease.Owner) }, true, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, false, err
+		}
+
+		if !s.takeOver(lock) {
+			return nil, false, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// takeOver removes the lock file if it is expired (or unreadable) and returns true if it was removed
+func (s *FileTokenStore) takeOver(lock string) bool {
+	if leaseActive(lock) {
+		return false
+	}
+
+	// Renomeia antes de remover para que apenas uma instância assuma o lock expirado
+	stale := lock + "." + randomID()
+	if err := os.Rename(lock, stale); err != nil {
+		return false
+	}
+
+	// Outra instância assumiu o lock entre a leitura e a renomeação: devolve o lock dela sem sobrescrever
+	// um lock criado nesse intervalo (Link falha se o arquivo já existir); se não for possível, descarta-o
+	if leaseActive(stale) {
+		os.Link(stale, lock)
+		os.Remove(stale)
+		return false
+	}
+
+	os.Remove(stale)
+	return true
+}
+
+// release removes the lock file if it still belongs to the owner
+func (s *FileTokenStore) release(lock, owner string) {
+	if current, ok := readLease(lock); ok && current.Owner == owner {
+		os.Remove(lock)
+	}
+}
+
+// writeFile writes the file atomically (temporary file + rename)
+func (s *FileTokenStore) writeFile(name string, data []byte) error {
+	f, err := os.CreateTemp(s.dir, filepath.Base(name)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), name)
+}
+
+// path returns the file of the key with the given extension
+func (s *FileTokenStore) path(key, ext string) string {
+	return filepath.Join(s.dir, filepath.Base(key)+ext)
+}
+
+// leaseActive returns true if the lease file is not expired. A file that cannot be read yet
+// (being written by its owner) is active for DefaultTokenLeaseTTL after its modification.
+func leaseActive(name string) bool {
+	if lease, ok := readLease(name); ok {
+		return lease.ExpiresAt.After(time.Now())
+	}
+
+	info, err := os.Stat(name)
+	if err != nil {
+		return false
+	}
+	return time.Since(info.ModTime()) < DefaultTokenLeaseTTL
+}
+
+// readLease reads a lease file
+func readLease(name string) (fileLease, bool) {
+	var lease fileLease
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return lease, false
+	}
+	if err := json.Unmarshal(data, &lease); err != nil {
+		return lease, false
+	}
+	return lease, true
+}
+
+// randomID returns a random hexadecimal identifier
+func randomID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
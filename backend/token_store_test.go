@@ -0,0 +1,71 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileTokenStoreLease(t *testing.T) {
+	s, err := NewFileTokenStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	release, ok, err := s.Lease(ctx, "key", time.Minute)
+	if err != nil || !ok {
+		t.Fatalf("Lease() = %v, %v, want the lease", ok, err)
+	}
+
+	// O lease ativo não é assumido por outra instância
+	if _, ok, err := s.Lease(ctx, "key", time.Minute); err != nil || ok {
+		t.Fatalf("second Lease() = %v, %v, want no lease", ok, err)
+	}
+
+	release()
+	release2, ok, err := s.Lease(ctx, "key", time.Minute)
+	if err != nil || !ok {
+		t.Fatalf("Lease() after release = %v, %v, want the lease", ok, err)
+	}
+	release2()
+}
+
+func TestFileTokenStoreLeaseTakeOver(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileTokenStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock := filepath.Join(dir, "key.lock")
+
+	// Lock expirado deixado por uma instância que caiu
+	data, _ := json.Marshal(fileLease{Owner: "crashed", ExpiresAt: time.Now().Add(-time.Second)})
+	if err := os.WriteFile(lock, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	release, ok, err := s.Lease(context.Background(), "key", time.Minute)
+	if err != nil || !ok {
+		t.Fatalf("Lease() = %v, %v, want the expired lock taken over", ok, err)
+	}
+
+	// O release do dono anterior não remove o lock assumido
+	s.release(lock, "crashed")
+	if !leaseActive(lock) {
+		t.Fatal("lock removed by its previous owner")
+	}
+
+	release()
+	if _, err := os.Stat(lock); !os.IsNotExist(err) {
+		t.Errorf("lock not removed on release: %v", err)
+	}
+
+	// Nenhum arquivo temporário do takeOver fica para trás
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("files left in the store: %v", entries)
+	}
+}
@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/raniellyferreira/interbank-go/auth"
+)
+
+// newTokenBackend returns a backend whose tokens are valid for 500ms (half of expires_in=1),
+// counting the token requests
+func newTokenBackend(t *testing.T) (*BackendImplement, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":1}`, n)
+	}))
+	t.Cleanup(srv.Close)
+
+	b := NewBackendWithCredentials(auth.NewCredentials("id", "secret")).
+		SetURL(srv.URL).
+		SetRateLimiter(nil).
+		SetTokenJitter(0)
+	t.Cleanup(func() { b.Close() })
+
+	return b, &requests
+}
+
+func TestTokenRenewal(t *testing.T) {
+	b, requests := newTokenBackend(t)
+	ctx := context.Background()
+
+	first, err := b.Token(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// O token foi usado: é renovado em segundo plano antes de expirar
+	if _, err := b.Token(ctx); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(700 * time.Millisecond)
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("token requests = %d, want 2 (background renewal)", got)
+	}
+
+	// A chamada seguinte usa o token renovado, sem nova requisição
+	renewed, err := b.Token(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renewed.GetAccessToken() == first.GetAccessToken() || requests.Load() != 2 {
+		t.Fatalf("token = %s after %d requests, want the renewed token", renewed.GetAccessToken(), requests.Load())
+	}
+}
+
+func TestTokenRenewalSkipsUnusedTokens(t *testing.T) {
+	b, requests := newTokenBackend(t)
+
+	if _, err := b.Token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(700 * time.Millisecond)
+	if got := requests.Load(); got != 1 {
+		t.Errorf("token requests = %d, want 1 (unused token is not renewed)", got)
+	}
+}
+
+func TestTokenRenewalStops(t *testing.T) {
+	tests := []struct {
+		name string
+		stop func(b *BackendImplement)
+	}{
+		{"close", func(b *BackendImplement) { b.Close() }},
+		{"disabled", func(b *BackendImplement) { b.SetTokenRenewal(false) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, requests := newTokenBackend(t)
+			ctx := context.Background()
+
+			for i := 0; i < 2; i++ {
+				if _, err := b.Token(ctx); err != nil {
+					t.Fatal(err)
+				}
+			}
+			tt.stop(b)
+
+			time.Sleep(700 * time.Millisecond)
+			if got := requests.Load(); got != 1 {
+				t.Errorf("token requests = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestScopedTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		disable bool
+		want    int32
+	}{
+		{"enabled by default", false, 2},
+		{"disabled", true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, requests := newTokenBackend(t)
+			if tt.disable {
+				b.SetScopedTokens(false)
+			}
+			ctx := context.Background()
+
+			for _, scopes := range [][]auth.Scope{{"extrato.read"}, {"pix.write"}, {"extrato.read"}} {
+				if _, err := b.Token(ctx, scopes...); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := requests.Load(); got != tt.want {
+				t.Errorf("token requests = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
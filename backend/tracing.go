@@ -0,0 +1,100 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/erros"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerName é o nome do tracer (instrumentation scope) usado nos spans
+const TracerName = "github.com/raniellyferreira/interbank-go"
+
+type operationKey struct{}
+
+// WithOperation returns a context whose calls are identified by the operation name (e.g. "pix.CriarCobrancaImediata")
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// OperationFromContext returns the operation name set in the context, if any
+func OperationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
+
+// SetTracerProvider sets the OpenTelemetry tracer provider of the calls (nil disables the tracing, the default).
+// Each service method starts a client span named after it (e.g. pix.CriarCobrancaImediata), child of the
+// span of the context, and the token refreshes are child spans of the call that triggered them.
+func (c *BackendImplement) SetTracerProvider(provider trace.TracerProvider) *BackendImplement {
+	if provider == nil {
+		provider = noop.NewTracerProvider()
+	}
+	c.tracer = provider.Tracer(TracerName)
+	return c
+}
+
+// StartCall starts the call of a service method: sets the operation name in the context and starts its span.
+// The returned function ends the span and must be called when the method returns.
+func (c *BackendImplement) StartCall(ctx context.Context, operation string) (context.Context, func()) {
+	ctx = WithOperation(ctx, operation)
+
+	ctx, span := c.tracer.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, func() { span.End() }
+}
+
+// traceCall records the result of the call in the span of the context
+func (c *BackendImplement) traceCall(req *resty.Request, method, endpoint string, attempts int, resp *resty.Response, err error) {
+	span := trace.SpanFromContext(req.Context())
+	if !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("http.request.method", method),
+		attribute.String("url.template", endpoint),
+		attribute.Int("http.request.resend_count", attempts-1),
+	)
+	if base, perr := url.Parse(c.GetURL()); perr == nil {
+		span.SetAttributes(attribute.String("server.address", base.Hostname()))
+	}
+
+	if resp != nil && resp.RawResponse != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode()))
+	}
+
+	switch {
+	case err != nil:
+		recordError(span, err)
+	case resp != nil && resp.IsError():
+		if errResp, ok := resp.Error().(*erros.Response); ok {
+			recordError(span, errResp.WithStatus(resp.StatusCode()))
+		} else {
+			recordError(span, erros.NewErrorWithStatus(resp.StatusCode(), resp.String()))
+		}
+	}
+}
+
+// startSpan starts an internal span of the backend (e.g. token refresh)
+func (c *BackendImplement) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return c.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// recordError marks the span as failed with the error
+func recordError(span trace.Span, err error) {
+	span.RecordError(err)
+
+	var errResp *erros.Response
+	if errors.As(err, &errResp) {
+		span.SetAttributes(attribute.String("error.type", errResp.GetStatus()))
+		span.SetStatus(codes.Error, errResp.GetTitle())
+		return
+	}
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,21 @@
+package banking
+
+import "context"
+
+// API é o conjunto de métodos do serviço de Banking, implementado por *Service.
+// Use-a como dependência para substituir o serviço por um mock nos testes.
+type API interface {
+	// Extrato e saldo
+	ConsultarSaldo(ctx context.Context, dataSaldo string) (*ConsultarSaldoResponse, error)
+	ConsultarExtrato(ctx context.Context, dataInicio, dataFim string) (*ConsultarExtratoResponse, error)
+	ConsultarExtratoCompleto(ctx context.Context, req *ConsultarExtratoCompletoRequest) (*ConsultarExtratoResponse, error)
+	ExportarExtrato(ctx context.Context, dataInicio, dataFim string) (*ExportarExtratoResponse, error)
+
+	// Webhook
+	CriarWebhook(ctx context.Context, tipo TipoWebhook, webhookUrl string) error
+	ConsultarWebhook(ctx context.Context, tipo TipoWebhook) (*WebhookResponse, error)
+	DeletarWebhook(ctx context.Context, tipo TipoWebhook) error
+	ConsultarWebhooksCallbacks(ctx context.Context, tipo TipoWebhook, req *WebhookCallbacksRequest) (*WebhookCallbacksResponse, error)
+}
+
+var _ API = (*Service)(nil)
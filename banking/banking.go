@@ -7,11 +7,11 @@ const (
 )
 
 type Service struct {
-	backend *backend.BackendImplement
+	backend backend.Backend
 }
 
 // NewService creates a new banking service
-func NewService(client *backend.BackendImplement) *Service {
+func NewService(client backend.Backend) *Service {
 	return &Service{
 		backend: client,
 	}
@@ -4,117 +4,68 @@ import (
 	"context"
 	"path"
 
-	"github.com/raniellyferreira/interbank-go/erros"
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
+	"github.com/raniellyferreira/interbank-go/backend"
 	interutils "github.com/raniellyferreira/interbank-go/utils"
 )
 
 // ExportarExtrato exports the account statement
 func (c *Service) ExportarExtrato(ctx context.Context, dataInicio, dataFim string) (*ExportarExtratoResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &ExportarExtratoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "banking.ExportarExtrato",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(endpointBanking, "extrato", "exportar"),
+		Query: map[string]string{
+			"dataInicio": dataInicio,
+			"dataFim":    dataFim,
+		},
+		Scopes: []auth.Scope{auth.ScopeExtratoRead},
+		Result: result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&ExportarExtratoResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	if dataInicio != "" {
-		req.SetQueryParam("dataInicio", dataInicio)
-	}
-
-	if dataFim != "" {
-		req.SetQueryParam("dataFim", dataFim)
-	}
-
-	resp, err := req.Get(path.Join(endpointBanking, "extrato", "exportar"))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*ExportarExtratoResponse), nil
+	return result, nil
 }
 
 // ConsultarExtratoCompleto consults the account statement
 func (c *Service) ConsultarExtratoCompleto(ctx context.Context, req *ConsultarExtratoCompletoRequest) (*ConsultarExtratoResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &ConsultarExtratoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "banking.ConsultarExtratoCompleto",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(endpointBanking, "extrato", "completo"),
+		Query:     interutils.StructToMap(req),
+		Scopes:    []auth.Scope{auth.ScopeExtratoRead},
+		Result:    result,
+	}); err != nil {
 		return nil, err
 	}
 
-	request := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&ConsultarExtratoResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	// Set query parameters
-	if req != nil {
-		request.SetQueryParams(interutils.StructToMap(req))
-	}
-
-	resp, err := request.Get(path.Join(endpointBanking, "extrato", "completo"))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*ConsultarExtratoResponse), nil
+	return result, nil
 }
 
 // ConsultarExtrato consults the account statement
 func (c *Service) ConsultarExtrato(ctx context.Context, dataInicio, dataFim string) (*ConsultarExtratoResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &ConsultarExtratoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "banking.ConsultarExtrato",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(endpointBanking, "extrato"),
+		Query: map[string]string{
+			"dataInicio": dataInicio,
+			"dataFim":    dataFim,
+		},
+		Scopes: []auth.Scope{auth.ScopeExtratoRead},
+		Result: result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&ConsultarExtratoResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	if dataInicio != "" {
-		req.SetQueryParam("dataInicio", dataInicio)
-	}
-
-	if dataFim != "" {
-		req.SetQueryParam("dataFim", dataFim)
-	}
-
-	resp, err := req.Get(path.Join(endpointBanking, "extrato"))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*ConsultarExtratoResponse), nil
+	return result, nil
 }
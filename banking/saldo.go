@@ -4,7 +4,9 @@ import (
 	"context"
 	"path"
 
-	"github.com/raniellyferreira/interbank-go/erros"
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
+	"github.com/raniellyferreira/interbank-go/backend"
 )
 
 // ConsultarSaldoResponse represents the response of the ConsultarSaldo method
@@ -14,34 +16,20 @@ type ConsultarSaldoResponse struct {
 
 // ConsultarSaldo consults the balance of the account
 func (c *Service) ConsultarSaldo(ctx context.Context, dataSaldo string) (*ConsultarSaldoResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &ConsultarSaldoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "banking.ConsultarSaldo",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(endpointBanking, "saldo"),
+		Query: map[string]string{
+			"dataSaldo": dataSaldo,
+		},
+		Scopes: []auth.Scope{auth.ScopeExtratoRead},
+		Result: result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&ConsultarSaldoResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	if dataSaldo != "" {
-		req.SetQueryParam("dataSaldo", dataSaldo)
-	}
-
-	resp, err := req.Get(path.Join(endpointBanking, "saldo"))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*ConsultarSaldoResponse), nil
+	return result, nil
 }
@@ -0,0 +1,272 @@
+package calendario
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Feriado representa um feriado bancário
+type Feriado struct {
+	Data time.Time // Data do feriado (00:00 UTC)
+	Nome string    // Nome do feriado
+}
+
+// data é a chave de um dia no calendário
+type data struct {
+	ano int
+	mes time.Month
+	dia int
+}
+
+func dataDe(t time.Time) data {
+	ano, mes, dia := t.Date()
+	return data{ano, mes, dia}
+}
+
+func (d data) time() time.Time {
+	return time.Date(d.ano, d.mes, d.dia, 0, 0, 0, 0, time.UTC)
+}
+
+// Calendario é um calendário de dias úteis bancários.
+// Sábados, domingos, feriados nacionais e feriados personalizados não são dias úteis.
+type Calendario struct {
+	mu           sync.RWMutex
+	anos         map[int]map[data]string // Feriados nacionais calculados por ano
+	customizados map[data]string         // Feriados adicionados pelo usuário
+}
+
+// Padrao é o calendário de feriados bancários nacionais usado pelas funções do pacote
+var Padrao = New()
+
+// New creates a new calendar with the national banking holidays
+func New() *Calendario {
+	return &Calendario{
+		anos:         make(map[int]map[data]string),
+		customizados: make(map[data]string),
+	}
+}
+
+// AdicionarFeriado adds a custom holiday (e.g. a state or municipal holiday)
+func (c *Calendario) AdicionarFeriado(dia time.Time, nome string) *Calendario {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.customizados[dataDe(dia)] = nome
+	return c
+}
+
+// RemoverFeriado removes a custom holiday
+func (c *Calendario) RemoverFeriado(dia time.Time) *Calendario {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.customizados, dataDe(dia))
+	return c
+}
+
+// Feriado returns the holiday name and true if the date is a holiday
+func (c *Calendario) Feriado(dia time.Time) (string, bool) {
+	d := dataDe(dia)
+
+	c.mu.RLock()
+	nome, ok := c.customizados[d]
+	nacionais, calculado := c.anos[d.ano]
+	c.mu.RUnlock()
+
+	if ok {
+		return nome, true
+	}
+
+	if !calculado {
+		nacionais = c.nacionais(d.ano)
+	}
+
+	nome, ok = nacionais[d]
+	return nome, ok
+}
+
+// Feriados returns the holidays of the year (national and custom) sorted by date
+func (c *Calendario) Feriados(ano int) []Feriado {
+	nacionais := c.nacionais(ano)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	todos := make(map[data]string, len(nacionais))
+	for d, nome := range nacionais {
+		todos[d] = nome
+	}
+	for d, nome := range c.customizados {
+		if d.ano == ano {
+			todos[d] = nome
+		}
+	}
+
+	feriados := make([]Feriado, 0, len(todos))
+	for d, nome := range todos {
+		feriados = append(feriados, Feriado{Data: d.time(), Nome: nome})
+	}
+	sort.Slice(feriados, func(i, j int) bool { return feriados[i].Data.Before(feriados[j].Data) })
+
+	return feriados
+}
+
+// DiaUtil returns true if the date is a business day
+func (c *Calendario) DiaUtil(dia time.Time) bool {
+	if dia.Weekday() == time.Saturday || dia.Weekday() == time.Sunday {
+		return false
+	}
+
+	_, feriado := c.Feriado(dia)
+	return !feriado
+}
+
+// ProximoDiaUtil returns the first business day after the date
+func (c *Calendario) ProximoDiaUtil(dia time.Time) time.Time {
+	return c.AjustarDiaUtil(dia.AddDate(0, 0, 1))
+}
+
+// DiaUtilAnterior returns the last business day before the date
+func (c *Calendario) DiaUtilAnterior(dia time.Time) time.Time {
+	dia = dia.AddDate(0, 0, -1)
+	for !c.DiaUtil(dia) {
+		dia = dia.AddDate(0, 0, -1)
+	}
+	return dia
+}
+
+// AjustarDiaUtil returns the date itself if it is a business day, or the next business day
+func (c *Calendario) AjustarDiaUtil(dia time.Time) time.Time {
+	for !c.DiaUtil(dia) {
+		dia = dia.AddDate(0, 0, 1)
+	}
+	return dia
+}
+
+// AdicionarDiasUteis adds n business days to the date (n can be negative)
+func (c *Calendario) AdicionarDiasUteis(dia time.Time, n int) time.Time {
+	for ; n > 0; n-- {
+		dia = c.ProximoDiaUtil(dia)
+	}
+	for ; n < 0; n++ {
+		dia = c.DiaUtilAnterior(dia)
+	}
+	return dia
+}
+
+// DiasUteisEntre counts the business days in the interval (inicio, fim].
+// Returns a negative number if fim is before inicio.
+func (c *Calendario) DiasUteisEntre(inicio, fim time.Time) int {
+	inicio, fim = dataDe(inicio).time(), dataDe(fim).time()
+	if fim.Before(inicio) {
+		return -c.DiasUteisEntre(fim, inicio)
+	}
+
+	dias := 0
+	for d := inicio.AddDate(0, 0, 1); !d.After(fim); d = d.AddDate(0, 0, 1) {
+		if c.DiaUtil(d) {
+			dias++
+		}
+	}
+	return dias
+}
+
+// nacionais returns the national banking holidays of the year, computing them once
+func (c *Calendario) nacionais(ano int) map[data]string {
+	c.mu.RLock()
+	feriados, ok := c.anos[ano]
+	c.mu.RUnlock()
+	if ok {
+		return feriados
+	}
+
+	feriados = feriadosNacionais(ano)
+
+	c.mu.Lock()
+	c.anos[ano] = feriados
+	c.mu.Unlock()
+
+	return feriados
+}
+
+// feriadosNacionais returns the national banking holidays of the year, including the movable ones
+// (Carnaval, Sexta-feira Santa and Corpus Christi)
+func feriadosNacionais(ano int) map[data]string {
+	pascoa := Pascoa(ano)
+	movel := func(dias int) data {
+		return dataDe(pascoa.AddDate(0, 0, dias))
+	}
+
+	feriados := map[data]string{
+		{ano, time.January, 1}:   "Confraternização Universal",
+		movel(-48):               "Carnaval",
+		movel(-47):               "Carnaval",
+		movel(-2):                "Sexta-feira Santa",
+		{ano, time.April, 21}:    "Tiradentes",
+		{ano, time.May, 1}:       "Dia do Trabalho",
+		movel(60):                "Corpus Christi",
+		{ano, time.September, 7}: "Independência do Brasil",
+		{ano, time.October, 12}:  "Nossa Senhora Aparecida",
+		{ano, time.November, 2}:  "Finados",
+		{ano, time.November, 15}: "Proclamação da República",
+		{ano, time.December, 25}: "Natal",
+	}
+
+	// Dia Nacional de Zumbi e da Consciência Negra (Lei 14.759/2023)
+	if ano >= 2024 {
+		feriados[data{ano, time.November, 20}] = "Dia Nacional de Zumbi e da Consciência Negra"
+	}
+
+	return feriados
+}
+
+// Pascoa retorna o domingo de Páscoa do ano (algoritmo de Meeus/Jones/Butcher)
+func Pascoa(ano int) time.Time {
+	a := ano % 19
+	b := ano / 100
+	c := ano % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	mes := (h + l - 7*m + 114) / 31
+	dia := (h+l-7*m+114)%31 + 1
+
+	return time.Date(ano, time.Month(mes), dia, 0, 0, 0, 0, time.UTC)
+}
+
+// DiaUtil returns true if the date is a business day in the default calendar
+func DiaUtil(dia time.Time) bool {
+	return Padrao.DiaUtil(dia)
+}
+
+// ProximoDiaUtil returns the first business day after the date in the default calendar
+func ProximoDiaUtil(dia time.Time) time.Time {
+	return Padrao.ProximoDiaUtil(dia)
+}
+
+// DiaUtilAnterior returns the last business day before the date in the default calendar
+func DiaUtilAnterior(dia time.Time) time.Time {
+	return Padrao.DiaUtilAnterior(dia)
+}
+
+// AjustarDiaUtil returns the date itself if it is a business day, or the next business day in the default calendar
+func AjustarDiaUtil(dia time.Time) time.Time {
+	return Padrao.AjustarDiaUtil(dia)
+}
+
+// AdicionarDiasUteis adds n business days to the date in the default calendar
+func AdicionarDiasUteis(dia time.Time, n int) time.Time {
+	return Padrao.AdicionarDiasUteis(dia, n)
+}
+
+// DiasUteisEntre counts the business days in the interval (inicio, fim] in the default calendar
+func DiasUteisEntre(inicio, fim time.Time) int {
+	return Padrao.DiasUteisEntre(inicio, fim)
+}
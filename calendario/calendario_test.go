@@ -0,0 +1,145 @@
+package calendario
+
+import (
+	"testing"
+	"time"
+)
+
+func dia(ano int, mes time.Month, d int) time.Time {
+	return time.Date(ano, mes, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPascoa(t *testing.T) {
+	tests := []struct {
+		ano  int
+		want time.Time
+	}{
+		{2000, dia(2000, time.April, 23)},
+		{2008, dia(2008, time.March, 23)},
+		{2011, dia(2011, time.April, 24)},
+		{2019, dia(2019, time.April, 21)},
+		{2024, dia(2024, time.March, 31)},
+		{2025, dia(2025, time.April, 20)},
+		{2026, dia(2026, time.April, 5)},
+		{2038, dia(2038, time.April, 25)},
+	}
+
+	for _, tt := range tests {
+		if got := Pascoa(tt.ano); !got.Equal(tt.want) {
+			t.Errorf("Pascoa(%d) = %s, want %s", tt.ano, got.Format(time.DateOnly), tt.want.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestFeriadosMoveis(t *testing.T) {
+	tests := []struct {
+		data time.Time
+		nome string
+	}{
+		{dia(2024, time.February, 12), "Carnaval"},
+		{dia(2024, time.February, 13), "Carnaval"},
+		{dia(2024, time.March, 29), "Sexta-feira Santa"},
+		{dia(2024, time.May, 30), "Corpus Christi"},
+		{dia(2025, time.March, 3), "Carnaval"},
+		{dia(2025, time.March, 4), "Carnaval"},
+		{dia(2025, time.April, 18), "Sexta-feira Santa"},
+		{dia(2025, time.June, 19), "Corpus Christi"},
+		{dia(2026, time.February, 16), "Carnaval"},
+		{dia(2026, time.February, 17), "Carnaval"},
+		{dia(2026, time.April, 3), "Sexta-feira Santa"},
+		{dia(2026, time.June, 4), "Corpus Christi"},
+	}
+
+	c := New()
+	for _, tt := range tests {
+		nome, ok := c.Feriado(tt.data)
+		if !ok || nome != tt.nome {
+			t.Errorf("Feriado(%s) = %q, %v, want %q", tt.data.Format(time.DateOnly), nome, ok, tt.nome)
+		}
+		if c.DiaUtil(tt.data) {
+			t.Errorf("DiaUtil(%s) = true, want false", tt.data.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestConscienciaNegra(t *testing.T) {
+	c := New()
+
+	// Feriado nacional a partir de 2024 (Lei 14.759/2023)
+	if !c.DiaUtil(dia(2023, time.November, 20)) {
+		t.Error("2023-11-20 should be a business day")
+	}
+	if c.DiaUtil(dia(2024, time.November, 20)) {
+		t.Error("2024-11-20 should be a holiday")
+	}
+	if c.DiaUtil(dia(2025, time.November, 20)) {
+		t.Error("2025-11-20 should be a holiday")
+	}
+}
+
+func TestDiasUteis(t *testing.T) {
+	c := New()
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"ajustar dia útil", c.AjustarDiaUtil(dia(2024, time.March, 1)), dia(2024, time.March, 1)},
+		{"ajustar feriado", c.AjustarDiaUtil(dia(2024, time.November, 20)), dia(2024, time.November, 21)},
+		{"ajustar fim de semana", c.AjustarDiaUtil(dia(2024, time.November, 16)), dia(2024, time.November, 18)},
+		{"próximo dia útil após carnaval", c.ProximoDiaUtil(dia(2024, time.February, 9)), dia(2024, time.February, 14)},
+		{"dia útil anterior à páscoa", c.DiaUtilAnterior(dia(2024, time.April, 1)), dia(2024, time.March, 28)},
+		{"adicionar dias úteis", c.AdicionarDiasUteis(dia(2024, time.December, 23), 3), dia(2024, time.December, 27)},
+		{"subtrair dias úteis", c.AdicionarDiasUteis(dia(2024, time.December, 26), -2), dia(2024, time.December, 23)},
+	}
+
+	for _, tt := range tests {
+		if !tt.got.Equal(tt.want) {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got.Format(time.DateOnly), tt.want.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestDiasUteisEntre(t *testing.T) {
+	c := New()
+
+	tests := []struct {
+		inicio, fim time.Time
+		want        int
+	}{
+		{dia(2024, time.December, 24), dia(2024, time.December, 24), 0},
+		{dia(2024, time.December, 24), dia(2024, time.December, 26), 1}, // 25 é Natal
+		{dia(2024, time.December, 26), dia(2024, time.December, 24), -1},
+		{dia(2024, time.February, 9), dia(2024, time.February, 16), 3}, // carnaval e fim de semana
+		{dia(2024, time.November, 15), dia(2024, time.November, 22), 4},
+	}
+
+	for _, tt := range tests {
+		if got := c.DiasUteisEntre(tt.inicio, tt.fim); got != tt.want {
+			t.Errorf("DiasUteisEntre(%s, %s) = %d, want %d", tt.inicio.Format(time.DateOnly), tt.fim.Format(time.DateOnly), got, tt.want)
+		}
+	}
+}
+
+func TestFeriadoPersonalizado(t *testing.T) {
+	sexta := dia(2025, time.July, 11)
+
+	c := New().AdicionarFeriado(sexta, "Feriado municipal")
+	if c.DiaUtil(sexta) {
+		t.Error("custom holiday should not be a business day")
+	}
+	if got := c.ProximoDiaUtil(dia(2025, time.July, 10)); !got.Equal(dia(2025, time.July, 14)) {
+		t.Errorf("ProximoDiaUtil = %s, want 2025-07-14", got.Format(time.DateOnly))
+	}
+
+	// O calendário padrão não é alterado
+	if !Padrao.DiaUtil(sexta) || !New().DiaUtil(sexta) {
+		t.Error("custom holidays should not leak into other calendars")
+	}
+
+	c.RemoverFeriado(sexta)
+	if !c.DiaUtil(sexta) {
+		t.Error("removed holiday should be a business day")
+	}
+}
@@ -0,0 +1,18 @@
+package cobranca
+
+import "context"
+
+// API é o conjunto de métodos do serviço de Cobrança, implementado por *Service.
+// Use-a como dependência para substituir o serviço por um mock nos testes.
+type API interface {
+	Emitir(ctx context.Context, request *EmitirRequest) (*EmitirResponse, error)
+	Sumario(ctx context.Context, request *SumarioRequest) (*[]SumarioItem, error)
+
+	// Webhook
+	CriarWebhook(ctx context.Context, request *CriarWebhookRequest) error
+	ConsultarWebhook(ctx context.Context) (*Webhook, error)
+	DeletarWebhook(ctx context.Context) error
+	ConsultarWebhookCallbacks(ctx context.Context, request *ConsultarWebhookCallbacksRequest) (*WebhookCallbacksResponse, error)
+}
+
+var _ API = (*Service)(nil)
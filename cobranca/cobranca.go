@@ -3,49 +3,36 @@ package cobranca
 import (
 	"context"
 
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
 	"github.com/raniellyferreira/interbank-go/backend"
-	"github.com/raniellyferreira/interbank-go/erros"
 )
 
 const cobrancaEndpoint = "cobranca/v3/cobrancas"
 
 type Service struct {
-	backend *backend.BackendImplement
+	backend backend.Backend
 }
 
-func NewService(client *backend.BackendImplement) *Service {
+func NewService(client backend.Backend) *Service {
 	return &Service{
 		backend: client,
 	}
 }
 
 func (c *Service) Emitir(ctx context.Context, request *EmitirRequest) (*EmitirResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &EmitirResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "cobranca.Emitir",
+		Method:    resty.MethodPost,
+		Endpoint:  cobrancaEndpoint,
+		Scopes:    []auth.Scope{auth.ScopeBoletoCobrancaWrite},
+		Body:      request,
+		Result:    result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&EmitirResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken()).
-		SetHeader("Content-Type", "application/json").
-		SetBody(request)
-
-	resp, err := req.Post(cobrancaEndpoint)
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*EmitirResponse), nil
+	return result, nil
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"path"
 
-	"github.com/raniellyferreira/interbank-go/erros"
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
+	"github.com/raniellyferreira/interbank-go/backend"
 	interutils "github.com/raniellyferreira/interbank-go/utils"
 )
 
@@ -60,34 +62,18 @@ type SumarioItem struct {
 
 // Sumario busca o sumário de cobranças
 func (s *Service) Sumario(ctx context.Context, request *SumarioRequest) (*[]SumarioItem, error) {
-	token, err := s.backend.Token(ctx)
-	if err != nil {
+	result := &[]SumarioItem{}
+
+	if err := s.backend.Do(ctx, &backend.Call{
+		Operation: "cobranca.Sumario",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(cobrancaEndpoint, "sumario"),
+		Query:     interutils.StructToMap(request),
+		Scopes:    []auth.Scope{auth.ScopeBoletoCobrancaRead},
+		Result:    result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := s.backend.Req().
-		SetContext(ctx).
-		SetResult(&[]SumarioItem{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	if request != nil {
-		req.SetQueryParams(interutils.StructToMap(request))
-	}
-
-	resp, err := req.Get(path.Join(cobrancaEndpoint, "sumario"))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*[]SumarioItem), nil
+	return result, nil
 }
@@ -4,130 +4,73 @@ import (
 	"context"
 	"path"
 
-	"github.com/raniellyferreira/interbank-go/erros"
+	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
+	"github.com/raniellyferreira/interbank-go/auth"
+	"github.com/raniellyferreira/interbank-go/backend"
 	interutils "github.com/raniellyferreira/interbank-go/utils"
 )
 
 // CriarWebhook represents a response to create a webhook
 func (s *Service) CriarWebhook(ctx context.Context, request *CriarWebhookRequest) error {
-	token, err := s.backend.Token(ctx)
-	if err != nil {
-		return err
-	}
-
-	req := s.backend.Req().
-		SetContext(ctx).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken()).
-		SetHeader("Content-Type", "application/json").
-		SetBody(request)
-
-	resp, err := req.Put(path.Join(cobrancaEndpoint, "webhook"))
-	if err != nil {
-		return erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return errResp.WithStatus(resp.StatusCode())
-		}
-		return erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return nil
+	return s.backend.Do(ctx, &backend.Call{
+		Operation:  "cobranca.CriarWebhook",
+		Method:     resty.MethodPut,
+		Endpoint:   path.Join(cobrancaEndpoint, "webhook"),
+		Scopes:     []auth.Scope{auth.ScopeBoletoCobrancaWrite},
+		Idempotent: true,
+		Body:       request,
+	})
 }
 
 // ConsultarWebhook represents a response to get a webhook
 func (s *Service) ConsultarWebhook(ctx context.Context) (*Webhook, error) {
-	token, err := s.backend.Token(ctx)
-	if err != nil {
+	result := &Webhook{}
+
+	if err := s.backend.Do(ctx, &backend.Call{
+		Operation: "cobranca.ConsultarWebhook",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(cobrancaEndpoint, "webhook"),
+		Scopes:    []auth.Scope{auth.ScopeBoletoCobrancaRead},
+		Result:    result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := s.backend.Req().
-		SetContext(ctx).
-		SetResult(&Webhook{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	resp, err := req.Get(path.Join(cobrancaEndpoint, "webhook"))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*Webhook), nil
+	return result, nil
 }
 
 // ConsultarWebhookCallbacks represents a response to get a webhook callbacks
 func (s *Service) ConsultarWebhookCallbacks(ctx context.Context, request *ConsultarWebhookCallbacksRequest) (*WebhookCallbacksResponse, error) {
-	token, err := s.backend.Token(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	req := s.backend.Req().
-		SetContext(ctx).
-		SetResult(&WebhookCallbacksResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	if request != nil {
-		req.SetQueryParams(interutils.StructToMap(request))
-	}
+	result := &WebhookCallbacksResponse{}
 
-	resp, err := req.Get(path.Join(cobrancaEndpoint, "webhook", "callbacks"))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+	// uuid.UUID is an array, so omitempty never drops it: skip the zero value explicitly
+	query := interutils.StructToMap(request)
+	if request != nil && request.CodigoSolicitacao == uuid.Nil {
+		delete(query, "codigoSolicitacao")
 	}
 
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+	if err := s.backend.Do(ctx, &backend.Call{
+		Operation: "cobranca.ConsultarWebhookCallbacks",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(cobrancaEndpoint, "webhook", "callbacks"),
+		Query:     query,
+		Scopes:    []auth.Scope{auth.ScopeBoletoCobrancaRead},
+		Result:    result,
+	}); err != nil {
+		return nil, err
 	}
 
-	return resp.Result().(*WebhookCallbacksResponse), nil
+	return result, nil
 }
 
 // DeletarWebhook represents a response to delete a webhook
 func (s *Service) DeletarWebhook(ctx context.Context) error {
-	token, err := s.backend.Token(ctx)
-	if err != nil {
-		return err
-	}
-
-	req := s.backend.Req().
-		SetContext(ctx).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	resp, err := req.Delete(path.Join(cobrancaEndpoint, "webhook"))
-	if err != nil {
-		return erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return errResp.WithStatus(resp.StatusCode())
-		}
-		return erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return nil
+	return s.backend.Do(ctx, &backend.Call{
+		Operation:  "cobranca.DeletarWebhook",
+		Method:     resty.MethodDelete,
+		Endpoint:   path.Join(cobrancaEndpoint, "webhook"),
+		Scopes:     []auth.Scope{auth.ScopeBoletoCobrancaWrite},
+		Idempotent: true,
+	})
 }
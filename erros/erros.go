@@ -1,11 +1,17 @@
 package erros
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 )
 
+// ErrRateLimited é retornado quando a chamada excederia o limite de requisições do cliente
+var ErrRateLimited = errors.New("client-side rate limit exceeded")
+
 type Violation struct {
 	Reason   string `json:"razao"`
 	Property string `json:"propriedade,omitempty"`
@@ -23,6 +29,8 @@ type Response struct {
 	Message string `json:"message,omitempty"`
 
 	Violations []Violation `json:"violacoes,omitempty"`
+
+	cause error // Erro original das falhas de transporte
 }
 
 func (e *Response) JsonString() string {
@@ -46,9 +54,24 @@ func (e *Response) GetStatus() string {
 }
 
 func (e *Response) Error() string {
+	if e.Status == 0 && e.StatusText == "" {
+		return fmt.Sprintf("%s: %s", e.GetTitle(), e.GetMessage())
+	}
 	return fmt.Sprintf("%s %s: %s", e.GetStatus(), e.GetTitle(), e.GetMessage())
 }
 
+// Unwrap returns the original error of a transport failure, if any
+func (e *Response) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether the error matches the target: network timeouts match context.DeadlineExceeded
+// (cancellations and context deadlines already match context.Canceled and context.DeadlineExceeded
+// through Unwrap)
+func (e *Response) Is(target error) bool {
+	return target == context.DeadlineExceeded && e.cause != nil && isTimeout(e.cause)
+}
+
 func (e *Response) GetTitle() string {
 	if e.Title != "" {
 		return e.Title
@@ -63,6 +86,11 @@ func (e *Response) GetMessage() string {
 	return e.Detail
 }
 
+// IsTransport returns true if the request failed before a response was received (status zero)
+func (e *Response) IsTransport() bool {
+	return e.Status == 0 && e.cause != nil
+}
+
 func NewErrorWithStatus(status int, msg string) *Response {
 	return &Response{
 		Status:  status,
@@ -71,6 +99,31 @@ func NewErrorWithStatus(status int, msg string) *Response {
 	}
 }
 
+// NewTransportError returns the error of a request that failed before a response was received
+// (connection, TLS, timeout, cancellation or client-side rate limit), wrapping the original error
+func NewTransportError(err error) *Response {
+	var typed *Response
+	if errors.As(err, &typed) {
+		return typed
+	}
+
+	title := "Transport Error"
+	switch {
+	case errors.Is(err, context.Canceled):
+		title = "Canceled"
+	case errors.Is(err, context.DeadlineExceeded), isTimeout(err):
+		title = "Timeout"
+	case errors.Is(err, ErrRateLimited):
+		title = "Rate Limited"
+	}
+
+	return &Response{
+		Title:   title,
+		Message: err.Error(),
+		cause:   err,
+	}
+}
+
 func NewFromError(err error) *Response {
 	if typed, ok := err.(*Response); ok {
 		return typed
@@ -79,5 +132,11 @@ func NewFromError(err error) *Response {
 		Status:  http.StatusInternalServerError,
 		Title:   "Internal Server Error",
 		Message: err.Error(),
+		cause:   err,
 	}
 }
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
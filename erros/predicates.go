@@ -0,0 +1,66 @@
+package erros
+
+import (
+	"errors"
+	"net/http"
+)
+
+// StatusOf returns the HTTP status of the error, if it is a *Response with a status
+func StatusOf(err error) (int, bool) {
+	var resp *Response
+	if errors.As(err, &resp) && resp.Status != 0 {
+		return resp.Status, true
+	}
+	return 0, false
+}
+
+// IsNotFound returns true if the resource was not found (404)
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized returns true if the credentials or the token were rejected (401)
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden returns true if the credentials lack the scope or the permission for the operation (403)
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsConflict returns true if the request conflicts with the current state of the resource (409)
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited returns true if the request was rejected by the API (429) or by the client-side rate limiter
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited) || hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsValidation returns true if the request was rejected as invalid (400 or 422, or with violations)
+func IsValidation(err error) bool {
+	var resp *Response
+	if !errors.As(err, &resp) {
+		return false
+	}
+	return resp.Status == http.StatusBadRequest || resp.Status == http.StatusUnprocessableEntity || len(resp.Violations) > 0
+}
+
+// IsServerError returns true if the API failed to process the request (5xx)
+func IsServerError(err error) bool {
+	status, ok := StatusOf(err)
+	return ok && status >= http.StatusInternalServerError
+}
+
+// IsTransport returns true if the request failed before a response was received
+func IsTransport(err error) bool {
+	var resp *Response
+	return errors.As(err, &resp) && resp.IsTransport()
+}
+
+func hasStatus(err error, status int) bool {
+	s, ok := StatusOf(err)
+	return ok && s == status
+}
@@ -0,0 +1,61 @@
+package erros
+
+import (
+	"errors"
+	"strings"
+)
+
+// FieldViolation é uma violação com a propriedade decomposta em caminho, para associar o erro ao campo de um formulário
+type FieldViolation struct {
+	Path   []string // Caminho da propriedade (por exemplo ["cobs", "0", "valor", "original"])
+	Field  string   // Caminho normalizado, separado por pontos (por exemplo "cobs.0.valor.original")
+	Reason string
+	Value  string
+}
+
+// FieldPath splits a violation property into its path: "cobs[0].valor.original" returns ["cobs", "0", "valor", "original"]
+func FieldPath(property string) []string {
+	property = strings.NewReplacer("[", ".", "]", "").Replace(property)
+
+	var path []string
+	for _, part := range strings.Split(property, ".") {
+		if part = strings.TrimSpace(part); part != "" {
+			path = append(path, part)
+		}
+	}
+	return path
+}
+
+// FieldViolations returns the violations with the property split into a field path
+func (e *Response) FieldViolations() []FieldViolation {
+	violations := make([]FieldViolation, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		path := FieldPath(v.Property)
+		violations = append(violations, FieldViolation{
+			Path:   path,
+			Field:  strings.Join(path, "."),
+			Reason: v.Reason,
+			Value:  v.Value,
+		})
+	}
+	return violations
+}
+
+// ViolationsByField returns the reasons of the violations grouped by the normalized field path.
+// Violations without property are grouped under the empty field.
+func (e *Response) ViolationsByField() map[string][]string {
+	fields := make(map[string][]string, len(e.Violations))
+	for _, v := range e.FieldViolations() {
+		fields[v.Field] = append(fields[v.Field], v.Reason)
+	}
+	return fields
+}
+
+// ViolationsByField returns the violations of the error grouped by field path (nil if it has none)
+func ViolationsByField(err error) map[string][]string {
+	var resp *Response
+	if !errors.As(err, &resp) || len(resp.Violations) == 0 {
+		return nil
+	}
+	return resp.ViolationsByField()
+}
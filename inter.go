@@ -2,6 +2,7 @@ package inter
 
 import (
 	"context"
+	"log/slog"
 	"os"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/raniellyferreira/interbank-go/banking"
 	"github.com/raniellyferreira/interbank-go/cobranca"
 	"github.com/raniellyferreira/interbank-go/pix"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Client is a client for the Inter service
@@ -29,20 +31,20 @@ type Client struct {
 // NewClientWithCredentials creates a new client with the given credentials
 func NewClientWithCredentials(creds *auth.Credentials) *Client {
 	// Create the backend
-	backend := backend.NewBackendWithCredentials(creds)
+	b := backend.NewBackendWithCredentials(creds)
 
 	// Set the default URL
-	backend.SetURL("https://cdpj.partners.bancointer.com.br")
+	b.SetURL(backend.ProductionURL)
 
 	// Set the default timeout
-	backend.SetTimeout(60 * time.Second)
+	b.SetTimeout(60 * time.Second)
 
 	return &Client{
-		backend: backend,
+		backend: b,
 
-		Pix:      pix.NewService(backend),
-		Banking:  banking.NewService(backend),
-		Cobranca: cobranca.NewService(backend),
+		Pix:      pix.NewService(b),
+		Banking:  banking.NewService(b),
+		Cobranca: cobranca.NewService(b),
 	}
 }
 
@@ -70,14 +72,22 @@ func (c *Client) SetTimeout(timeout time.Duration) *Client {
 	return c
 }
 
-// Token returns the current token or fetches a new one if it's expired (thread-safe)
-func (c *Client) Token(ctx context.Context) (*auth.Token, error) {
-	return c.backend.Token(ctx)
+// Token returns the current token or fetches a new one if it's expired (thread-safe).
+// Scopes are ignored when scoped tokens are disabled (see SetScopedTokens).
+func (c *Client) Token(ctx context.Context, scopes ...auth.Scope) (*auth.Token, error) {
+	return c.backend.Token(ctx, scopes...)
+}
+
+// SetScopedTokens sets whether a separate token is requested for the scopes declared by each service method,
+// instead of one token with all the scopes of the credentials (enabled by default, see backend.SetScopedTokens)
+func (c *Client) SetScopedTokens(enabled bool) *Client {
+	c.backend.SetScopedTokens(enabled)
+	return c
 }
 
 // UseSandBox sets the base URL to the sandbox environment (set URL to https://cdpj-sandbox.partners.uatinter.co)
 func (c *Client) UseSandBox() *Client {
-	c.SetURL("https://cdpj-sandbox.partners.uatinter.co")
+	c.SetURL(backend.SandboxURL)
 	return c
 }
 
@@ -87,9 +97,114 @@ func (c *Client) SetURL(url string) *Client {
 	return c
 }
 
-// SetAccountNumber sets the account number for the client
+// SetAccountNumber sets the default account number, sent in each request that has no other account
+// (see ForAccount and backend.WithAccount)
 func (c *Client) SetAccountNumber(accountNumber string) *Client {
 	c.accountNumber = accountNumber
-	c.backend.SetHeader("x-conta-corrente", accountNumber)
+	c.backend.SetAccountNumber(accountNumber)
+	return c
+}
+
+// ForAccount returns a view of the client whose calls are made on the given account number.
+// The view shares the backend, the tokens and the settings with the client (setters affect both),
+// so that several accounts can be used concurrently from one process.
+func (c *Client) ForAccount(accountNumber string) *Client {
+	b := c.backend.ForAccount(accountNumber)
+
+	return &Client{
+		backend:       c.backend,
+		accountNumber: accountNumber,
+
+		Pix:      pix.NewService(b),
+		Banking:  banking.NewService(b),
+		Cobranca: cobranca.NewService(b),
+	}
+}
+
+// SetRetryPolicy sets the retry policy for transient failures (see backend.RetryPolicy).
+// Per-call overrides can be set in the context with backend.WithRetryPolicy, backend.WithMaxAttempts,
+// backend.WithRetryNonIdempotent and backend.WithoutRetryNonIdempotent.
+func (c *Client) SetRetryPolicy(policy backend.RetryPolicy) *Client {
+	c.backend.SetRetryPolicy(policy)
+	return c
+}
+
+// SetMaxAttempts sets the maximum number of attempts of each call, including the first one (1 disables retries)
+func (c *Client) SetMaxAttempts(attempts int) *Client {
+	policy := c.backend.GetRetryPolicy()
+	policy.MaxAttempts = attempts
+	c.backend.SetRetryPolicy(policy)
+	return c
+}
+
+// SetRateLimit sets the client-side limit of a group of endpoints, identified by the path prefix (e.g. "pix/v2/cob").
+// The default limits are in backend.DefaultRateLimits.
+func (c *Client) SetRateLimit(group string, limit backend.RateLimit) *Client {
+	c.backend.SetRateLimit(group, limit)
+	return c
+}
+
+// SetRateLimiter sets the client-side rate limiter (nil disables the rate limiting)
+func (c *Client) SetRateLimiter(limiter *backend.RateLimiter) *Client {
+	c.backend.SetRateLimiter(limiter)
+	return c
+}
+
+// RateLimitBudgets returns the current budget of each group of endpoints
+func (c *Client) RateLimitBudgets() []backend.RateLimitBudget {
+	if limiter := c.backend.RateLimiter(); limiter != nil {
+		return limiter.Budgets()
+	}
+	return nil
+}
+
+// SetTokenRefresh sets how long before the expiration the token is renewed (skew, default 180s)
+// and the maximum random anticipation of the background renewal (jitter, default 30s)
+func (c *Client) SetTokenRefresh(skew, jitter time.Duration) *Client {
+	c.backend.SetTokenSkew(skew).SetTokenJitter(jitter)
+	return c
+}
+
+// SetTokenRenewal enables or disables the background renewal of the token (enabled by default).
+// Only tokens used since the last renewal are renewed; call Close to stop the renewal.
+func (c *Client) SetTokenRenewal(enabled bool) *Client {
+	c.backend.SetTokenRenewal(enabled)
+	return c
+}
+
+// Close stops the background tasks of the client (token renewal)
+func (c *Client) Close() error {
+	return c.backend.Close()
+}
+
+// SetTokenStore sets the store used to share the token between replicas (see backend.TokenStore)
+func (c *Client) SetTokenStore(store backend.TokenStore) *Client {
+	c.backend.SetTokenStore(store)
+	return c
+}
+
+// SetLogger sets the structured logger of the API calls (nil disables the logging, the default).
+// Request and response bodies are logged only at debug level, without secrets, tokens and CPF/CNPJ numbers.
+func (c *Client) SetLogger(logger *slog.Logger) *Client {
+	c.backend.SetLogger(logger)
+	return c
+}
+
+// SetTracerProvider enables the OpenTelemetry tracing of the API calls with the given provider
+// (e.g. otel.GetTracerProvider()); nil disables the tracing, the default
+func (c *Client) SetTracerProvider(provider trace.TracerProvider) *Client {
+	c.backend.SetTracerProvider(provider)
+	return c
+}
+
+// SetMetrics sets the metrics collector of the API calls (e.g. backend.NewPrometheusMetrics()); nil disables the metrics
+func (c *Client) SetMetrics(metrics backend.Metrics) *Client {
+	c.backend.SetMetrics(metrics)
+	return c
+}
+
+// Use adds middlewares to the chain of the API calls, after the built-in ones (see backend.Middleware)
+func (c *Client) Use(middlewares ...backend.Middleware) *Client {
+	c.backend.Use(middlewares...)
 	return c
 }
@@ -0,0 +1,56 @@
+package pix
+
+import "context"
+
+// API é o conjunto de métodos do serviço de Pix, implementado por *Service.
+// Use-a como dependência para substituir o serviço por um mock nos testes.
+type API interface {
+	// Pix
+	Consultar(ctx context.Context, endToEndId string) (*Pix, error)
+	ConsultarRecebidos(ctx context.Context, request *RecebidosRequest) (*RecebidosResponse, error)
+	PagarCobranca(ctx context.Context, tipoCob TipoCobranca, txID, valor string) (*PagarCobrancaResponse, error)
+	SimularPagamento(ctx context.Context, tipoCob TipoCobranca, txID, valor string, config *AguardarConfig) (*SimulacaoPagamento, error)
+
+	// Devoluções
+	ConsultarDevolucao(ctx context.Context, endToEndId, uniqId string) (*DevolucaoResponse, error)
+	SolicitarDevolucao(ctx context.Context, request *SolicitarDevolucaoPixRequest) (*DevolucaoResponse, error)
+	ValidarDevolucao(ctx context.Context, request *SolicitarDevolucaoPixRequest) error
+	SolicitarDevolucaoValidada(ctx context.Context, request *SolicitarDevolucaoPixRequest) (*DevolucaoResponse, error)
+	AguardarDevolucao(ctx context.Context, endToEndId, id string, config *AguardarConfig) (*DevolucaoResponse, error)
+	AguardarDevolucaoSolicitada(ctx context.Context, request *SolicitarDevolucaoPixRequest, config *AguardarConfig) (*DevolucaoResponse, error)
+	AcompanharDevolucoes(ctx context.Context, devolucoes []DevolucaoRef, config *AguardarConfig) <-chan *DevolucaoResultado
+
+	// Cobranças imediatas
+	CriarCobrancaImediata(ctx context.Context, request *CobrancaImediataRequest) (*CobrancaImediataResponse, error)
+	CriarCobrancaImediataComTxID(ctx context.Context, txID string, request *CobrancaImediataRequest) (*CobrancaImediataResponse, error)
+	EditarCobrancaImediata(ctx context.Context, txID string, request *CobrancaImediataRequest) (*CobrancaImediataResponse, error)
+	CancelarCobrancaImediata(ctx context.Context, txID string) (*CobrancaImediataResponse, error)
+	ConsultarCobrancaImediata(ctx context.Context, txID string) (*CobrancaImediataResponse, error)
+	ConsultarCobrancasImediatas(ctx context.Context, request *ConsultarCobrancasImediatasRequest) (*ConsultarCobrancasImediatasResponse, error)
+
+	// Cobranças com vencimento
+	CriarCobrancaComVencimentoETxID(ctx context.Context, txID string, request *CobrancaComVencimentoRequest) (*CobrancaComVencimentoResponse, error)
+	EditarCobrancaComVencimento(ctx context.Context, txID string, request *CobrancaComVencimentoRequest) (*CobrancaComVencimentoResponse, error)
+	ConsultarCobrancaComVencimento(ctx context.Context, txID string) (*CobrancaComVencimentoResponse, error)
+	ConsultarCobrancasComVencimento(ctx context.Context, request *ConsultarCobrancasComVencimentoRequest) (*ConsultarCobrancasComVencimentoResponse, error)
+
+	// Lotes de cobranças com vencimento
+	CriarLoteCobrancaComVencimento(ctx context.Context, id int64, request *LoteCobrancaComVencimentoRequest) error
+	EditarLoteCobrancaComVencimento(ctx context.Context, id int64, request *EditarLoteCobrancaComVencimentoRequest) error
+	ConsultarLoteCobrancaComVencimento(ctx context.Context, id int64) (*LoteCobrancaComVencimentoResponse, error)
+	ConsultarLotesCobrancaComVencimento(ctx context.Context, request *ConsultarLotesCobrancaComVencimentoRequest) (*ConsultarLotesCobrancaComVencimentoResponse, error)
+
+	// Locations
+	CriarLoc(ctx context.Context, tipoCob TipoCobranca) (*LocResponse, error)
+	ConsultarLoc(ctx context.Context, id int64) (*LocResponse, error)
+	ConsultarLocs(ctx context.Context, request *ConsultarLocsRequest) (*ConsultarLocsResponse, error)
+	DesvincularLoc(ctx context.Context, id int64) (*LocResponse, error)
+
+	// Webhook
+	CriarWebhook(ctx context.Context, chave, webhookUrl string) error
+	ConsultarWebhook(ctx context.Context, chave string) (*WebhookResponse, error)
+	DeletarWebhook(ctx context.Context, chave string) error
+	ConsultarWebhookCallbacks(ctx context.Context, request *ConsultarWebhooksCallbacksRequest) (*CallbacksResponse, error)
+}
+
+var _ API = (*Service)(nil)
@@ -0,0 +1,251 @@
+package chave
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Tipo representa o tipo de uma chave Pix
+type Tipo string
+
+const (
+	TipoCPF      Tipo = "CPF"      // CPF com 11 dígitos
+	TipoCNPJ     Tipo = "CNPJ"     // CNPJ com 14 caracteres (numérico ou alfanumérico)
+	TipoEmail    Tipo = "EMAIL"    // Endereço de e-mail
+	TipoTelefone Tipo = "TELEFONE" // Telefone no formato E.164 (+55)
+	TipoEVP      Tipo = "EVP"      // Chave aleatória (UUID)
+)
+
+var (
+	// ErrChaveVazia é retornado quando a chave está vazia
+	ErrChaveVazia = errors.New("chave pix: empty key")
+
+	// ErrTipoDesconhecido é retornado quando não é possível identificar o tipo da chave
+	ErrTipoDesconhecido = errors.New("chave pix: unknown key type")
+
+	// ErrChaveInvalida é retornado quando a chave não passa na validação do seu tipo
+	ErrChaveInvalida = errors.New("chave pix: invalid key")
+)
+
+const (
+	maxEmailLength = 77 // Tamanho máximo de uma chave e-mail no DICT
+)
+
+var (
+	reEmail    = regexp.MustCompile(`^[a-z0-9.!#$&'*+/=?^_{|}~-]+@[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?(?:\.[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?)+$`)
+	reTelefone = regexp.MustCompile(`^\+55[1-9]{2}9?[0-9]{8}$`)
+	reE164     = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+	reEVP      = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	reEVPRaw   = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	reCNPJ     = regexp.MustCompile(`^[0-9A-Z]{12}[0-9]{2}$`)
+	reDigitos  = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Chave representa uma chave Pix normalizada
+type Chave struct {
+	Tipo  Tipo   // Tipo da chave
+	Valor string // Valor da chave no formato aceito pelo DICT
+}
+
+// String returns the normalized key
+func (c Chave) String() string {
+	return c.Valor
+}
+
+// Parse detecta o tipo da chave, normaliza a formatação e valida o seu conteúdo
+func Parse(chave string) (*Chave, error) {
+	chave = strings.TrimSpace(chave)
+	if chave == "" {
+		return nil, ErrChaveVazia
+	}
+
+	tipo := DetectarTipo(chave)
+	if tipo == "" {
+		return nil, fmt.Errorf("%w: %q", ErrTipoDesconhecido, chave)
+	}
+
+	valor := normalizar(tipo, chave)
+	if !valida(tipo, valor) {
+		return nil, fmt.Errorf("%w: %s %q", ErrChaveInvalida, tipo, chave)
+	}
+
+	return &Chave{Tipo: tipo, Valor: valor}, nil
+}
+
+// Normalizar retorna a chave no formato aceito pelo DICT
+func Normalizar(chave string) (string, error) {
+	c, err := Parse(chave)
+	if err != nil {
+		return "", err
+	}
+	return c.Valor, nil
+}
+
+// Validar retorna um erro caso a chave seja inválida
+func Validar(chave string) error {
+	_, err := Parse(chave)
+	return err
+}
+
+// DetectarTipo identifica o tipo da chave a partir do seu formato.
+// Retorna uma string vazia quando o tipo não pode ser identificado.
+//
+// A detecção segue as regras do DICT: telefones sempre começam com "+",
+// e-mails contêm "@" e uma sequência de 11 dígitos é sempre tratada como CPF.
+func DetectarTipo(chave string) Tipo {
+	chave = strings.TrimSpace(chave)
+	if chave == "" {
+		return ""
+	}
+
+	switch {
+	case strings.Contains(chave, "@"):
+		return TipoEmail
+	case strings.HasPrefix(chave, "+"):
+		return TipoTelefone
+	}
+
+	lower := strings.ToLower(chave)
+	if reEVP.MatchString(lower) || reEVPRaw.MatchString(lower) {
+		return TipoEVP
+	}
+
+	documento := limparDocumento(chave)
+	switch {
+	case len(documento) == 11 && reDigitos.MatchString(documento):
+		return TipoCPF
+	case len(documento) == 14 && reCNPJ.MatchString(documento):
+		return TipoCNPJ
+	}
+
+	return ""
+}
+
+// normalizar aplica as regras de formatação de cada tipo
+func normalizar(tipo Tipo, chave string) string {
+	switch tipo {
+	case TipoCPF, TipoCNPJ:
+		return limparDocumento(chave)
+	case TipoEmail:
+		return strings.ToLower(chave)
+	case TipoTelefone:
+		return "+" + somenteDigitos(chave)
+	case TipoEVP:
+		evp := strings.ToLower(chave)
+		if len(evp) == 32 {
+			evp = evp[0:8] + "-" + evp[8:12] + "-" + evp[12:16] + "-" + evp[16:20] + "-" + evp[20:32]
+		}
+		return evp
+	}
+	return chave
+}
+
+// valida verifica uma chave já normalizada
+func valida(tipo Tipo, valor string) bool {
+	switch tipo {
+	case TipoCPF:
+		return ValidarCPF(valor)
+	case TipoCNPJ:
+		return ValidarCNPJ(valor)
+	case TipoEmail:
+		return len(valor) <= maxEmailLength && reEmail.MatchString(valor)
+	case TipoTelefone:
+		return ValidarTelefone(valor)
+	case TipoEVP:
+		return reEVP.MatchString(valor)
+	}
+	return false
+}
+
+// ValidarCPF valida os dígitos verificadores de um CPF (com ou sem pontuação)
+func ValidarCPF(cpf string) bool {
+	cpf = limparDocumento(cpf)
+	if len(cpf) != 11 || !reDigitos.MatchString(cpf) || repetido(cpf) {
+		return false
+	}
+
+	for _, n := range []int{9, 10} {
+		soma := 0
+		for i := 0; i < n; i++ {
+			soma += int(cpf[i]-'0') * (n + 1 - i)
+		}
+		if digitoVerificador(soma) != int(cpf[n]-'0') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidarCNPJ valida os dígitos verificadores de um CNPJ (com ou sem pontuação).
+// Aceita também o CNPJ alfanumérico, em que os 12 primeiros caracteres podem ser letras.
+func ValidarCNPJ(cnpj string) bool {
+	cnpj = limparDocumento(cnpj)
+	if !reCNPJ.MatchString(cnpj) || repetido(cnpj) {
+		return false
+	}
+
+	pesos := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	for _, n := range []int{12, 13} {
+		soma := 0
+		for i := 0; i < n; i++ {
+			// Para o CNPJ alfanumérico o valor de cada caractere é o seu código ASCII menos 48
+			soma += int(cnpj[i]-'0') * pesos[len(pesos)-n+i]
+		}
+		if digitoVerificador(soma) != int(cnpj[n]-'0') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidarTelefone valida um telefone brasileiro no formato E.164 (+55 DDD número)
+func ValidarTelefone(telefone string) bool {
+	return reE164.MatchString(telefone) && reTelefone.MatchString(telefone)
+}
+
+// digitoVerificador calcula o dígito verificador pelo módulo 11
+func digitoVerificador(soma int) int {
+	resto := soma % 11
+	if resto < 2 {
+		return 0
+	}
+	return 11 - resto
+}
+
+// repetido retorna true se todos os caracteres forem iguais (ex: 111.111.111-11)
+func repetido(s string) bool {
+	return strings.Count(s, s[:1]) == len(s)
+}
+
+// limparDocumento remove a pontuação de um CPF/CNPJ e converte as letras para maiúsculas
+func limparDocumento(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+			continue
+		}
+		switch r {
+		case '.', '-', '/', ' ':
+		default:
+			// Caractere inválido para documentos, mantém para falhar na validação
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// somenteDigitos remove todos os caracteres que não são dígitos
+func somenteDigitos(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
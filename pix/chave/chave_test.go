@@ -0,0 +1,92 @@
+package chave
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidarCPF(t *testing.T) {
+	tests := []struct {
+		cpf  string
+		want bool
+	}{
+		{"529.982.247-25", true},
+		{"52998224725", true},
+		{"168.995.350-09", true},
+		{"529.982.247-24", false}, // segundo dígito errado
+		{"529.982.247-15", false}, // primeiro dígito errado
+		{"111.111.111-11", false}, // dígitos repetidos
+		{"5299822472", false},     // tamanho
+		{"5299822472A", false},    // letra
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidarCPF(tt.cpf); got != tt.want {
+			t.Errorf("ValidarCPF(%q) = %v, want %v", tt.cpf, got, tt.want)
+		}
+	}
+}
+
+func TestValidarCNPJ(t *testing.T) {
+	tests := []struct {
+		cnpj string
+		want bool
+	}{
+		{"11.222.333/0001-81", true},
+		{"11222333000181", true},
+		{"12.ABC.345/01DE-35", true}, // alfanumérico
+		{"12abc34501de35", true},     // alfanumérico em minúsculas
+		{"11.222.333/0001-80", false},
+		{"11.222.333/0001-91", false},
+		{"12.ABC.345/01DE-36", false},
+		{"12.ABC.345/01DE-3A", false}, // dígitos verificadores são sempre numéricos
+		{"00.000.000/0000-00", false},
+		{"1122233300018", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidarCNPJ(tt.cnpj); got != tt.want {
+			t.Errorf("ValidarCNPJ(%q) = %v, want %v", tt.cnpj, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		chave string
+		tipo  Tipo
+		valor string
+		err   error
+	}{
+		{"529.982.247-25", TipoCPF, "52998224725", nil},
+		{"11.222.333/0001-81", TipoCNPJ, "11222333000181", nil},
+		{"12.abc.345/01de-35", TipoCNPJ, "12ABC34501DE35", nil},
+		{" Fulano@Example.com ", TipoEmail, "fulano@example.com", nil},
+		{"+55 (11) 98765-4321", TipoTelefone, "+5511987654321", nil},
+		{"123E4567-E89B-12D3-A456-426614174000", TipoEVP, "123e4567-e89b-12d3-a456-426614174000", nil},
+		{"123e4567e89b12d3a456426614174000", TipoEVP, "123e4567-e89b-12d3-a456-426614174000", nil},
+		{"529.982.247-24", "", "", ErrChaveInvalida},
+		{"+1 202 555 0100", "", "", ErrChaveInvalida},
+		{"chave", "", "", ErrTipoDesconhecido},
+		{"  ", "", "", ErrChaveVazia},
+	}
+
+	for _, tt := range tests {
+		c, err := Parse(tt.chave)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Parse(%q) error = %v, want %v", tt.chave, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tt.chave, err)
+			continue
+		}
+		if c.Tipo != tt.tipo || c.Valor != tt.valor {
+			t.Errorf("Parse(%q) = %s %q, want %s %q", tt.chave, c.Tipo, c.Valor, tt.tipo, tt.valor)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package pix
+
+import (
+	"github.com/raniellyferreira/interbank-go/pix/chave"
+)
+
+// normalizarChave valida a chave Pix do recebedor e a retorna no formato aceito pelo DICT.
+// Em edições (opcional), uma chave vazia é mantida para não alterar o campo.
+func normalizarChave(valor string, opcional bool) (string, error) {
+	if opcional && valor == "" {
+		return "", nil
+	}
+	return chave.Normalizar(valor)
+}
+
+// cobrancaImediataComChave retorna uma cópia da requisição com a chave normalizada, sem alterar a original
+func cobrancaImediataComChave(request *CobrancaImediataRequest, opcional bool) (*CobrancaImediataRequest, error) {
+	if request == nil {
+		return nil, nil
+	}
+
+	normalizada, err := normalizarChave(request.Chave, opcional)
+	if err != nil {
+		return nil, err
+	}
+
+	copia := *request
+	copia.Chave = normalizada
+	return &copia, nil
+}
+
+// cobrancaComVencimentoComChave retorna uma cópia da requisição com a chave normalizada, sem alterar a original
+func cobrancaComVencimentoComChave(request *CobrancaComVencimentoRequest, opcional bool) (*CobrancaComVencimentoRequest, error) {
+	if request == nil {
+		return nil, nil
+	}
+
+	normalizada, err := normalizarChave(request.Chave, opcional)
+	if err != nil {
+		return nil, err
+	}
+
+	copia := *request
+	copia.Chave = normalizada
+	return &copia, nil
+}
+
+// loteCobrancaComVencimentoComChave retorna uma cópia do lote com a chave de cada cobrança normalizada
+func loteCobrancaComVencimentoComChave(request *LoteCobrancaComVencimentoRequest) (*LoteCobrancaComVencimentoRequest, error) {
+	if request == nil {
+		return nil, nil
+	}
+
+	copia := *request
+	copia.CobsV = make([]*LoteCobrancaComVencimentoItem, len(request.CobsV))
+	for i, item := range request.CobsV {
+		if item == nil {
+			continue
+		}
+
+		cob, err := cobrancaComVencimentoComChave(item.CobrancaComVencimentoRequest, false)
+		if err != nil {
+			return nil, err
+		}
+		copia.CobsV[i] = &LoteCobrancaComVencimentoItem{TxId: item.TxId, CobrancaComVencimentoRequest: cob}
+	}
+
+	return &copia, nil
+}
+
+// editarLoteCobrancaComVencimentoComChave retorna uma cópia das alterações do lote com as chaves preenchidas normalizadas
+func editarLoteCobrancaComVencimentoComChave(request *EditarLoteCobrancaComVencimentoRequest) (*EditarLoteCobrancaComVencimentoRequest, error) {
+	if request == nil {
+		return nil, nil
+	}
+
+	copia := *request
+	copia.CobsV = make([]*EditarLoteCobrancaComVencimentoItem, len(request.CobsV))
+	for i, item := range request.CobsV {
+		if item == nil {
+			continue
+		}
+
+		normalizada, err := normalizarChave(item.Chave, true)
+		if err != nil {
+			return nil, err
+		}
+
+		alterado := *item
+		alterado.Chave = normalizada
+		copia.CobsV[i] = &alterado
+	}
+
+	return &copia, nil
+}
@@ -0,0 +1,364 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+const (
+	// DefaultGerenciadorIntervalo é o intervalo padrão entre as consultas das cobranças acompanhadas
+	DefaultGerenciadorIntervalo = 15 * time.Second
+
+	// DefaultExpiracaoCobranca é a expiração (em segundos) assumida pelo Bacen quando o campo não é informado
+	DefaultExpiracaoCobranca = 86400
+)
+
+// CobEstado representa o estado de uma cobrança imediata acompanhada pelo gerenciador
+type CobEstado string
+
+const (
+	CobEstadoAtiva                        CobEstado = CobEstado(CobrancaStatusAtiva)
+	CobEstadoConcluida                    CobEstado = CobEstado(CobrancaStatusConcluida)
+	CobEstadoRemovidaPeloUsuarioRecebedor CobEstado = CobEstado(CobrancaStatusRemovidaPeloUsuarioRecebedor)
+	CobEstadoRemovidaPeloPSP              CobEstado = CobEstado(CobrancaStatusRemovidaPeloPSP)
+	CobEstadoExpirada                     CobEstado = "EXPIRADA" // Estado local: ATIVA após o prazo de expiração
+)
+
+// Final retorna true se a cobrança não pode mais mudar de estado
+func (e CobEstado) Final() bool {
+	return e != CobEstadoAtiva
+}
+
+// CobAcompanhada é uma cobrança imediata acompanhada pelo gerenciador
+type CobAcompanhada struct {
+	TxId      string                    // Identificador da transação
+	Estado    CobEstado                 // Estado atual
+	ExpiraEm  time.Time                 // Data e hora de expiração (criação + expiração)
+	Cobranca  *CobrancaImediataResponse // Última resposta obtida
+	Reemitida string                    // TxId da cobrança que substituiu esta, quando reemitida
+}
+
+// CobCallback é chamada quando uma cobrança acompanhada muda de estado
+type CobCallback func(ctx context.Context, cob *CobAcompanhada)
+
+// GerenciadorCobrancas acompanha o ciclo de vida de cobranças imediatas:
+// ATIVA -> CONCLUIDA | REMOVIDA_PELO_USUARIO_RECEBEDOR | REMOVIDA_PELO_PSP | EXPIRADA.
+// As cobranças em estado final deixam de ser acompanhadas após a chamada dos callbacks.
+type GerenciadorCobrancas struct {
+	service   API
+	intervalo time.Duration
+
+	onConcluida CobCallback
+	onExpirada  CobCallback
+	onRemovida  CobCallback
+
+	mu   sync.Mutex
+	cobs map[string]*CobAcompanhada
+}
+
+// NewGerenciadorCobrancas creates a new manager for immediate charges
+func NewGerenciadorCobrancas(service API) *GerenciadorCobrancas {
+	return &GerenciadorCobrancas{
+		service:   service,
+		intervalo: DefaultGerenciadorIntervalo,
+		cobs:      make(map[string]*CobAcompanhada),
+	}
+}
+
+// SetIntervalo sets the polling interval used by Executar
+func (g *GerenciadorCobrancas) SetIntervalo(intervalo time.Duration) *GerenciadorCobrancas {
+	g.intervalo = intervalo
+	return g
+}
+
+// OnConcluida sets the callback called when a charge is paid (CONCLUIDA)
+func (g *GerenciadorCobrancas) OnConcluida(callback CobCallback) *GerenciadorCobrancas {
+	g.onConcluida = callback
+	return g
+}
+
+// OnExpirada sets the callback called when a charge expires without being paid
+func (g *GerenciadorCobrancas) OnExpirada(callback CobCallback) *GerenciadorCobrancas {
+	g.onExpirada = callback
+	return g
+}
+
+// OnRemovida sets the callback called when a charge is removed by the receiver or by the PSP
+func (g *GerenciadorCobrancas) OnRemovida(callback CobCallback) *GerenciadorCobrancas {
+	g.onRemovida = callback
+	return g
+}
+
+// Criar cria uma cobrança imediata com o txID informado e passa a acompanhá-la
+func (g *GerenciadorCobrancas) Criar(ctx context.Context, txID string, request *CobrancaImediataRequest) (*CobAcompanhada, error) {
+	var (
+		cob *CobrancaImediataResponse
+		err error
+	)
+
+	if txID == "" {
+		cob, err = g.service.CriarCobrancaImediata(ctx, request)
+	} else {
+		cob, err = g.service.CriarCobrancaImediataComTxID(ctx, txID, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return g.acompanhar(cob), nil
+}
+
+// Acompanhar consulta uma cobrança imediata existente e passa a acompanhá-la
+func (g *GerenciadorCobrancas) Acompanhar(ctx context.Context, txID string) (*CobAcompanhada, error) {
+	cob, err := g.service.ConsultarCobrancaImediata(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	acompanhada := g.acompanhar(cob)
+	g.notificar(ctx, acompanhada)
+	return acompanhada, nil
+}
+
+// Obter retorna uma cópia do estado de uma cobrança acompanhada
+func (g *GerenciadorCobrancas) Obter(txID string) (CobAcompanhada, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	cob, ok := g.cobs[txID]
+	if !ok {
+		return CobAcompanhada{}, false
+	}
+	return *cob, true
+}
+
+// Cancelar cancela uma cobrança imediata (REMOVIDA_PELO_USUARIO_RECEBEDOR)
+func (g *GerenciadorCobrancas) Cancelar(ctx context.Context, txID string) error {
+	cob, err := g.service.CancelarCobrancaImediata(ctx, txID)
+	if err != nil {
+		return err
+	}
+
+	g.notificar(ctx, g.atualizar(cob))
+	return nil
+}
+
+// ReemissaoError é retornado por Reemitir quando a cobrança anterior foi cancelada mas a nova não pôde ser criada.
+// Use Criar com NovoTxId e Request para tentar novamente.
+type ReemissaoError struct {
+	TxId     string                   // TxId da cobrança anterior, já cancelada
+	NovoTxId string                   // TxId informado para a nova cobrança (vazio quando gerado pelo PSP)
+	Request  *CobrancaImediataRequest // Requisição da nova cobrança
+	Err      error                    // Erro da criação
+}
+
+func (e *ReemissaoError) Error() string {
+	return fmt.Sprintf("cobrança imediata %s cancelada, mas não foi reemitida: %v", e.TxId, e.Err)
+}
+
+func (e *ReemissaoError) Unwrap() error {
+	return e.Err
+}
+
+// Reemitir cancela a cobrança (se ainda ATIVA no PSP) e, somente após a confirmação do cancelamento, cria uma nova
+// com a mesma chave, devedor, valor, solicitação ao pagador e informações adicionais, com o novo prazo de
+// expiração (em segundos). Assim as duas cobranças nunca podem ser pagas. Quando novoTxID é vazio o txid é gerado pelo PSP.
+// Se a cobrança for concluída antes do cancelamento, nenhuma nova cobrança é criada e um erro é retornado.
+// Se a criação falhar, um *ReemissaoError é retornado com a requisição para uma nova tentativa.
+func (g *GerenciadorCobrancas) Reemitir(ctx context.Context, txID, novoTxID string, expiracao int32) (*CobAcompanhada, error) {
+	// Consulta o PSP para não reemitir uma cobrança paga nos últimos instantes
+	cob, err := g.service.ConsultarCobrancaImediata(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cob.Status == CobrancaStatusAtiva {
+		cancelada, err := g.service.CancelarCobrancaImediata(ctx, txID)
+		if err != nil {
+			// O PSP recusa o cancelamento de uma cobrança paga entre a consulta e o cancelamento
+			if atual, errConsulta := g.service.ConsultarCobrancaImediata(ctx, txID); errConsulta == nil && atual.Status == CobrancaStatusConcluida {
+				cob = atual
+			} else {
+				return nil, err
+			}
+		} else {
+			cob = cancelada
+		}
+	}
+
+	if cob.Status == CobrancaStatusConcluida {
+		g.notificar(ctx, g.atualizar(cob))
+		return nil, fmt.Errorf("cobrança imediata %s já foi concluída", txID)
+	}
+
+	request := &CobrancaImediataRequest{
+		Calendario:         &CalendarioSemVencimento{Expiracao: expiracao},
+		Devedor:            cob.Devedor,
+		Valor:              cob.Valor,
+		Chave:              cob.Chave,
+		InfoAdicionais:     cob.InfoAdicionais,
+		SolicitacaoPagador: cob.SolicitacaoPagador,
+	}
+
+	nova, err := g.Criar(ctx, novoTxID, request)
+	if err != nil {
+		g.notificar(ctx, g.atualizar(cob))
+		return nil, &ReemissaoError{TxId: txID, NovoTxId: novoTxID, Request: request, Err: err}
+	}
+
+	g.mu.Lock()
+	if anterior, ok := g.cobs[txID]; ok {
+		anterior.Reemitida = nova.TxId
+	}
+	g.mu.Unlock()
+
+	g.notificar(ctx, g.atualizar(cob))
+	return nova, nil
+}
+
+// Atualizar consulta todas as cobranças ativas uma vez, disparando os callbacks das que mudaram de estado
+func (g *GerenciadorCobrancas) Atualizar(ctx context.Context) error {
+	g.mu.Lock()
+	txIDs := make([]string, 0, len(g.cobs))
+	for txID, cob := range g.cobs {
+		if cob.Estado == CobEstadoAtiva {
+			txIDs = append(txIDs, txID)
+		}
+	}
+	g.mu.Unlock()
+
+	var errs []error
+	for _, txID := range txIDs {
+		cob, err := g.service.ConsultarCobrancaImediata(ctx, txID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", txID, err))
+			continue
+		}
+
+		g.notificar(ctx, g.atualizar(cob))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Executar chama Atualizar periodicamente até o contexto ser cancelado
+func (g *GerenciadorCobrancas) Executar(ctx context.Context) error {
+	for {
+		// Erros de consulta são transitórios, a cobrança é consultada novamente no próximo ciclo
+		if err := g.Atualizar(ctx); err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := interutils.Sleep(ctx, g.intervalo); err != nil {
+			return err
+		}
+	}
+}
+
+// acompanhar registers the charge, replacing any previous state for the same txid
+func (g *GerenciadorCobrancas) acompanhar(cob *CobrancaImediataResponse) *CobAcompanhada {
+	acompanhada := &CobAcompanhada{
+		TxId:     cob.TxId,
+		Cobranca: cob,
+		Estado:   estadoCobranca(cob, time.Now()),
+		ExpiraEm: expiracaoCobranca(cob),
+	}
+
+	g.mu.Lock()
+	g.cobs[cob.TxId] = acompanhada
+	g.mu.Unlock()
+
+	return acompanhada
+}
+
+// atualizar applies the PSP response to the tracked charge and returns it if the state changed
+func (g *GerenciadorCobrancas) atualizar(cob *CobrancaImediataResponse) *CobAcompanhada {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	acompanhada, ok := g.cobs[cob.TxId]
+	if !ok || acompanhada.Estado.Final() {
+		return nil
+	}
+
+	acompanhada.Cobranca = cob
+	if expiraEm := expiracaoCobranca(cob); !expiraEm.IsZero() {
+		acompanhada.ExpiraEm = expiraEm
+	}
+
+	estado := estadoCobranca(cob, time.Now())
+	if estado == acompanhada.Estado {
+		return nil
+	}
+
+	acompanhada.Estado = estado
+	copia := *acompanhada
+	return &copia
+}
+
+// notificar calls the callback of the new state and stops tracking final charges
+func (g *GerenciadorCobrancas) notificar(ctx context.Context, cob *CobAcompanhada) {
+	if cob == nil || !cob.Estado.Final() {
+		return
+	}
+
+	var callback CobCallback
+	switch cob.Estado {
+	case CobEstadoConcluida:
+		callback = g.onConcluida
+	case CobEstadoExpirada:
+		callback = g.onExpirada
+	case CobEstadoRemovidaPeloUsuarioRecebedor, CobEstadoRemovidaPeloPSP:
+		callback = g.onRemovida
+	}
+
+	if callback != nil {
+		callback(ctx, cob)
+	}
+
+	g.mu.Lock()
+	if atual, ok := g.cobs[cob.TxId]; ok && atual.Estado.Final() {
+		delete(g.cobs, cob.TxId)
+	}
+	g.mu.Unlock()
+}
+
+// estadoCobranca returns the state of the charge, EXPIRADA if it is still ATIVA after the expiration
+func estadoCobranca(cob *CobrancaImediataResponse, agora time.Time) CobEstado {
+	if cob.Status != CobrancaStatusAtiva {
+		return CobEstado(cob.Status)
+	}
+
+	if expiraEm := expiracaoCobranca(cob); !expiraEm.IsZero() && !agora.Before(expiraEm) {
+		return CobEstadoExpirada
+	}
+
+	return CobEstadoAtiva
+}
+
+// expiracaoCobranca returns the creation time plus the expiration, or zero if unknown
+func expiracaoCobranca(cob *CobrancaImediataResponse) time.Time {
+	if cob.Calendario == nil || cob.Calendario.Criacao == "" {
+		return time.Time{}
+	}
+
+	criacao := interutils.MustParseTime(cob.Calendario.Criacao)
+	if criacao.IsZero() {
+		return time.Time{}
+	}
+
+	expiracao := cob.Calendario.Expiracao
+	if expiracao == 0 {
+		expiracao = DefaultExpiracaoCobranca
+	}
+
+	return criacao.Add(time.Duration(expiracao) * time.Second)
+}
@@ -0,0 +1,132 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+// ErrDevolucaoInvalida é retornado quando a devolução solicitada não respeita os limites do Pix original
+var ErrDevolucaoInvalida = errors.New("devolução inválida")
+
+// LimiteDevolucao retorna o valor máximo (em centavos) que pode ser devolvido para a natureza informada,
+// sem descontar as devoluções já realizadas.
+//
+//   - Pix comum: ORIGINAL até o valor do próprio Pix; RETIRADA não é permitida;
+//   - Pix Saque: RETIRADA até o valor do saque;
+//   - Pix Troco: ORIGINAL até o valor da compra e RETIRADA até o valor do troco.
+func (p *Pix) LimiteDevolucao(natureza NaturezaDevolucaoPix) (int64, error) {
+	componentes := p.ComponentesValor
+	if componentes == nil {
+		componentes = &ComponentesValor{}
+	}
+
+	if componentes.Saque != nil && componentes.Troco != nil {
+		return 0, fmt.Errorf("%w: pix %s has both saque and troco", ErrDevolucaoInvalida, p.EndToEndID)
+	}
+
+	retirada := componentes.Saque
+	if retirada == nil {
+		retirada = componentes.Troco
+	}
+
+	switch natureza {
+	case NaturezaDevolucaoOriginal, "":
+		if retirada == nil {
+			return interutils.ParseCentavos(p.Valor)
+		}
+		if componentes.Original == nil {
+			return 0, nil
+		}
+		return interutils.ParseCentavos(componentes.Original.Valor)
+
+	case NaturezaDevolucaoRetirada:
+		if retirada == nil {
+			return 0, nil
+		}
+		return interutils.ParseCentavos(retirada.Valor)
+	}
+
+	return 0, fmt.Errorf("%w: unknown natureza %q", ErrDevolucaoInvalida, natureza)
+}
+
+// TotalDevolvido retorna o valor (em centavos) das devoluções existentes com a natureza informada,
+// ignorando as devoluções NAO_REALIZADO. Devoluções sem natureza são consideradas ORIGINAL.
+func (p *Pix) TotalDevolvido(natureza NaturezaDevolucaoPix) int64 {
+	if natureza == "" {
+		natureza = NaturezaDevolucaoOriginal
+	}
+
+	var total int64
+	for _, devolucao := range p.Devolucoes {
+		if devolucao == nil || devolucao.Status == DevolucaoStatusNaoRealizado {
+			continue
+		}
+
+		naturezaDevolucao := devolucao.Natureza
+		if naturezaDevolucao == "" {
+			naturezaDevolucao = NaturezaDevolucaoOriginal
+		}
+
+		if naturezaDevolucao == natureza {
+			total += interutils.FloatToCentavos(devolucao.Valor)
+		}
+	}
+	return total
+}
+
+// ValidarDevolucao verifica se a devolução pode ser solicitada para este Pix, considerando
+// a natureza, os componentes do valor e as devoluções já existentes
+func (p *Pix) ValidarDevolucao(request *SolicitarDevolucaoPixRequest) error {
+	if request.EndToEndID != "" && p.EndToEndID != "" && request.EndToEndID != p.EndToEndID {
+		return fmt.Errorf("%w: endToEndId %s does not match pix %s", ErrDevolucaoInvalida, request.EndToEndID, p.EndToEndID)
+	}
+
+	valor := interutils.FloatToCentavos(request.Valor)
+	if valor <= 0 {
+		return fmt.Errorf("%w: valor must be greater than zero", ErrDevolucaoInvalida)
+	}
+
+	natureza := request.Natureza
+	if natureza == "" {
+		natureza = NaturezaDevolucaoOriginal
+	}
+
+	limite, err := p.LimiteDevolucao(natureza)
+	if err != nil {
+		return err
+	}
+
+	if limite == 0 {
+		return fmt.Errorf("%w: natureza %s is not allowed for pix %s", ErrDevolucaoInvalida, natureza, p.EndToEndID)
+	}
+
+	disponivel := limite - p.TotalDevolvido(natureza)
+	if valor > disponivel {
+		return fmt.Errorf("%w: valor %s exceeds the available amount %s for natureza %s",
+			ErrDevolucaoInvalida, interutils.FormatCentavos(valor), interutils.FormatCentavos(max(disponivel, 0)), natureza)
+	}
+
+	return nil
+}
+
+// ValidarDevolucao consulta o Pix original e verifica se a devolução pode ser solicitada
+func (c *Service) ValidarDevolucao(ctx context.Context, request *SolicitarDevolucaoPixRequest) error {
+	pix, err := c.Consultar(ctx, request.EndToEndID)
+	if err != nil {
+		return err
+	}
+
+	return pix.ValidarDevolucao(request)
+}
+
+// SolicitarDevolucaoValidada valida a devolução com ValidarDevolucao antes de solicitá-la
+func (c *Service) SolicitarDevolucaoValidada(ctx context.Context, request *SolicitarDevolucaoPixRequest) (*DevolucaoResponse, error) {
+	if err := c.ValidarDevolucao(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return c.SolicitarDevolucao(ctx, request)
+}
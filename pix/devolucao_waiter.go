@@ -0,0 +1,155 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/raniellyferreira/interbank-go/erros"
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+const (
+	// DefaultAguardarIntervaloInicial é o intervalo inicial entre as consultas de uma devolução
+	DefaultAguardarIntervaloInicial = 1 * time.Second
+
+	// DefaultAguardarIntervaloMaximo é o intervalo máximo entre as consultas de uma devolução
+	DefaultAguardarIntervaloMaximo = 30 * time.Second
+
+	// DefaultAguardarJanelaNaoEncontrada é o tempo, a partir do início da espera, em que uma consulta com 404 é repetida
+	DefaultAguardarJanelaNaoEncontrada = 30 * time.Second
+)
+
+// Final retorna true se o status da devolução é terminal (DEVOLVIDO ou NAO_REALIZADO)
+func (s DevolucaoStatus) Final() bool {
+	return s == DevolucaoStatusDevolvido || s == DevolucaoStatusNaoRealizado
+}
+
+// AguardarConfig configura o intervalo entre as consultas de uma devolução.
+// O tempo máximo de espera é definido pelo deadline do contexto: os demais erros transitórios
+// (falhas de conexão, 429 e 5xx) são repetidos até ele, então use um contexto com deadline.
+type AguardarConfig struct {
+	IntervaloInicial    time.Duration // Intervalo antes da primeira consulta (default: 1s)
+	IntervaloMaximo     time.Duration // Intervalo máximo entre as consultas (default: 30s)
+	JanelaNaoEncontrada time.Duration // Tempo em que um 404 é repetido, a partir do início da espera (default: 30s)
+}
+
+// intervalos returns the configured intervals or the defaults
+func (c *AguardarConfig) intervalos() (time.Duration, time.Duration) {
+	inicial, maximo := DefaultAguardarIntervaloInicial, DefaultAguardarIntervaloMaximo
+	if c != nil && c.IntervaloInicial > 0 {
+		inicial = c.IntervaloInicial
+	}
+	if c != nil && c.IntervaloMaximo > 0 {
+		maximo = c.IntervaloMaximo
+	}
+	return inicial, maximo
+}
+
+// naoEncontradoAte returns until when a 404 is still retried
+func (c *AguardarConfig) naoEncontradoAte() time.Time {
+	janela := DefaultAguardarJanelaNaoEncontrada
+	if c != nil && c.JanelaNaoEncontrada > 0 {
+		janela = c.JanelaNaoEncontrada
+	}
+	return time.Now().Add(janela)
+}
+
+// DevolucaoRef identifica uma devolução
+type DevolucaoRef struct {
+	EndToEndID string // EndToEndId do pix devolvido
+	ID         string // Identificador da devolução (LocalUniqId)
+}
+
+// DevolucaoResultado é o resultado do acompanhamento de uma devolução
+type DevolucaoResultado struct {
+	DevolucaoRef
+
+	Devolucao *DevolucaoResponse // Última resposta obtida
+	Err       error              // Erro que interrompeu o acompanhamento
+}
+
+// AguardarDevolucao consulta a devolução com backoff exponencial até que ela atinja um status
+// terminal (DEVOLVIDO ou NAO_REALIZADO) ou até o deadline do contexto.
+// Uma devolução recém solicitada pode ainda não estar disponível, então o 404 é repetido durante
+// AguardarConfig.JanelaNaoEncontrada e depois retornado.
+// Em caso de timeout, a última resposta obtida é retornada junto com o erro do contexto.
+func (c *Service) AguardarDevolucao(ctx context.Context, endToEndId, id string, config *AguardarConfig) (*DevolucaoResponse, error) {
+	inicial, maximo := config.intervalos()
+	naoEncontradoAte := config.naoEncontradoAte()
+
+	var ultima *DevolucaoResponse
+	for attempt := 0; ; attempt++ {
+		if err := interutils.Sleep(ctx, interutils.Backoff(attempt, inicial, maximo)); err != nil {
+			return ultima, err
+		}
+
+		devolucao, err := c.ConsultarDevolucao(ctx, endToEndId, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ultima, ctx.Err()
+			}
+			if !consultaRecuperavel(err, naoEncontradoAte) {
+				return ultima, err
+			}
+			continue
+		}
+
+		ultima = devolucao
+		if devolucao.Status.Final() {
+			return devolucao, nil
+		}
+	}
+}
+
+// AguardarDevolucaoSolicitada aguarda a devolução retornada por SolicitarDevolucao
+func (c *Service) AguardarDevolucaoSolicitada(ctx context.Context, request *SolicitarDevolucaoPixRequest, config *AguardarConfig) (*DevolucaoResponse, error) {
+	return c.AguardarDevolucao(ctx, request.EndToEndID, request.GetLocalUniqId(), config)
+}
+
+// AcompanharDevolucoes aguarda várias devoluções em paralelo. Cada resultado é enviado no canal
+// retornado assim que a devolução atinge um status terminal ou falha. O canal é fechado quando
+// todas as devoluções forem concluídas ou o contexto for cancelado.
+func (c *Service) AcompanharDevolucoes(ctx context.Context, devolucoes []DevolucaoRef, config *AguardarConfig) <-chan *DevolucaoResultado {
+	resultados := make(chan *DevolucaoResultado, len(devolucoes))
+
+	var wg sync.WaitGroup
+	for _, ref := range devolucoes {
+		wg.Add(1)
+		go func(ref DevolucaoRef) {
+			defer wg.Done()
+
+			devolucao, err := c.AguardarDevolucao(ctx, ref.EndToEndID, ref.ID, config)
+			resultados <- &DevolucaoResultado{
+				DevolucaoRef: ref,
+				Devolucao:    devolucao,
+				Err:          err,
+			}
+		}(ref)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultados)
+	}()
+
+	return resultados
+}
+
+// consultaRecuperavel returns true if polling should continue after the error.
+// A devolução recém solicitada pode ainda não estar disponível para consulta (404),
+// por isso o 404 só é repetido até naoEncontradoAte.
+func consultaRecuperavel(err error, naoEncontradoAte time.Time) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if erros.IsNotFound(err) {
+		return time.Now().Before(naoEncontradoAte)
+	}
+
+	return erros.IsTransport(err) ||
+		erros.IsRateLimited(err) ||
+		erros.IsServerError(err)
+}
@@ -4,18 +4,19 @@ import (
 	"context"
 	"path"
 
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
 	"github.com/raniellyferreira/interbank-go/backend"
-	"github.com/raniellyferreira/interbank-go/erros"
 	interutils "github.com/raniellyferreira/interbank-go/utils"
 )
 
 const pixEndpoint = "pix/v2"
 
 type Service struct {
-	backend *backend.BackendImplement
+	backend backend.Backend
 }
 
-func NewService(client *backend.BackendImplement) *Service {
+func NewService(client backend.Backend) *Service {
 	return &Service{
 		backend: client,
 	}
@@ -23,157 +24,109 @@ func NewService(client *backend.BackendImplement) *Service {
 
 // ConsultarDevolucao para consultar a devolução de um pix
 func (c *Service) ConsultarDevolucao(ctx context.Context, endToEndId, uniqId string) (*DevolucaoResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &DevolucaoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "pix.ConsultarDevolucao",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(pixEndpoint, "pix", "{e2eId}", "devolucao", "{id}"),
+		PathParams: map[string]string{
+			"e2eId": endToEndId,
+			"id":    uniqId,
+		},
+		Scopes: []auth.Scope{auth.ScopePixRead},
+		Result: result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&DevolucaoResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	resp, err := req.Get(path.Join(pixEndpoint, "pix", endToEndId, "devolucao", uniqId))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*DevolucaoResponse), nil
+	return result, nil
 }
 
-// SolicitarDevolucao para solicitar a devolução de um pix
+// SolicitarDevolucao para solicitar a devolução de um pix.
+// Se o id já existir com o mesmo valor, a devolução existente é retornada; caso contrário, retorna ErrIDDevolucaoEmUso.
 func (c *Service) SolicitarDevolucao(ctx context.Context, request *SolicitarDevolucaoPixRequest) (*DevolucaoResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	if err := ValidarIDDevolucao(request.GetLocalUniqId()); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&DevolucaoResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken()).
-		SetHeader("Content-Type", "application/json").
-		SetBody(request)
-
-	resp, err := req.Put(path.Join(pixEndpoint, "pix", request.EndToEndID, "devolucao", request.GetLocalUniqId()))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
+	result := &DevolucaoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "pix.SolicitarDevolucao",
+		Method:    resty.MethodPut,
+		Endpoint:  path.Join(pixEndpoint, "pix", "{e2eId}", "devolucao", "{id}"),
+		PathParams: map[string]string{
+			"e2eId": request.EndToEndID,
+			"id":    request.GetLocalUniqId(),
+		},
+		Scopes:     []auth.Scope{auth.ScopePixWrite},
+		Idempotent: true,
+		Body:       request,
+		Result:     result,
+	}); err != nil {
+		if jaExiste(err) {
+			return c.devolucaoExistente(ctx, request, err)
 		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+		return nil, err
 	}
 
-	return resp.Result().(*DevolucaoResponse), nil
+	return result, nil
 }
 
 // Consultar pix recebidos
 func (c *Service) ConsultarRecebidos(ctx context.Context, request *RecebidosRequest) (*RecebidosResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &RecebidosResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "pix.ConsultarRecebidos",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(pixEndpoint, "pix"),
+		Query:     interutils.StructToMap(request),
+		Scopes:    []auth.Scope{auth.ScopePixRead},
+		Result:    result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&RecebidosResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken()).
-		SetQueryParams(interutils.StructToMap(request))
-
-	resp, err := req.Get(path.Join(pixEndpoint, "pix"))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*RecebidosResponse), nil
+	return result, nil
 }
 
 // Consultar para consultar um pix através de um determinado EndToEndId
 func (c *Service) Consultar(ctx context.Context, endToEndId string) (*Pix, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &Pix{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.Consultar",
+		Method:     resty.MethodGet,
+		Endpoint:   path.Join(pixEndpoint, "pix", "{e2eId}"),
+		PathParams: map[string]string{"e2eId": endToEndId},
+		Scopes:     []auth.Scope{auth.ScopePixRead},
+		Result:     result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&Pix{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	resp, err := req.Get(path.Join(pixEndpoint, "pix", endToEndId))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*Pix), nil
+	return result, nil
 }
 
-// PagarCobranca paga uma cobrança imediata. (SandBox apenas)
-func (c *Service) PagarCobranca(ctx context.Context, tipoCob TipoCobranca, txID, valor string) (*map[string]interface{}, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+// PagarCobranca paga uma cobrança imediata ou com vencimento. (SandBox apenas)
+func (c *Service) PagarCobranca(ctx context.Context, tipoCob TipoCobranca, txID, valor string) (*PagarCobrancaResponse, error) {
+	result := &PagarCobrancaResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.PagarCobranca",
+		Method:     resty.MethodPost,
+		Endpoint:   path.Join(pixEndpoint, string(tipoCob), "pagar", "{txid}"),
+		PathParams: map[string]string{"txid": txID},
+		Scopes:     []auth.Scope{auth.ScopePixWrite},
+		Body: &PagarCobrancaRequest{
+			Valor: valor,
+		},
+		Result: result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&map[string]interface{}{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken()).
-		SetHeader("Content-Type", "application/json").
-		SetBody(map[string]interface{}{
-			"valor": valor,
-		})
-
-	resp, err := req.Post(path.Join(pixEndpoint, string(tipoCob), "pagar", txID))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*map[string]interface{}), nil
+	return result, nil
 }
@@ -4,166 +4,140 @@ import (
 	"context"
 	"path"
 
-	"github.com/raniellyferreira/interbank-go/erros"
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
+	"github.com/raniellyferreira/interbank-go/backend"
 	interutils "github.com/raniellyferreira/interbank-go/utils"
 )
 
 // EditarCobrancaImediata edita uma cobrança imediata.
 func (c *Service) EditarCobrancaImediata(ctx context.Context, txID string, request *CobrancaImediataRequest) (*CobrancaImediataResponse, error) {
-	token, err := c.backend.Token(ctx)
+	request, err := cobrancaImediataComChave(request, true)
 	if err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&CobrancaImediataResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken()).
-		SetHeader("Content-Type", "application/json").
-		SetBody(request)
+	result := &CobrancaImediataResponse{}
 
-	resp, err := req.Patch(path.Join(pixEndpoint, "cob", txID))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.EditarCobrancaImediata",
+		Method:     resty.MethodPatch,
+		Endpoint:   path.Join(pixEndpoint, "cob", "{txid}"),
+		PathParams: map[string]string{"txid": txID},
+		Scopes:     []auth.Scope{auth.ScopeCobWrite},
+		Body:       request,
+		Result:     result,
+	}); err != nil {
+		return nil, err
 	}
 
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
+	return result, nil
+}
 
-	return resp.Result().(*CobrancaImediataResponse), nil
+// CancelarCobrancaImediata cancela uma cobrança imediata, alterando o status para REMOVIDA_PELO_USUARIO_RECEBEDOR.
+func (c *Service) CancelarCobrancaImediata(ctx context.Context, txID string) (*CobrancaImediataResponse, error) {
+	return c.EditarCobrancaImediata(ctx, txID, &CobrancaImediataRequest{
+		Status: CobrancaStatusRemovidaPeloUsuarioRecebedor,
+	})
 }
 
 // ConsultarCobrancasImediatas consulta cobranças imediatas.
 func (c *Service) ConsultarCobrancasImediatas(ctx context.Context, request *ConsultarCobrancasImediatasRequest) (*ConsultarCobrancasImediatasResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &ConsultarCobrancasImediatasResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "pix.ConsultarCobrancasImediatas",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(pixEndpoint, "cob"),
+		Query:     interutils.StructToMap(request),
+		Scopes:    []auth.Scope{auth.ScopeCobRead},
+		Result:    result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&ConsultarCobrancasImediatasResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	if request != nil {
-		req.SetQueryParams(interutils.StructToMap(request))
-	}
-
-	resp, err := req.Get(path.Join(pixEndpoint, "cob"))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*ConsultarCobrancasImediatasResponse), nil
+	return result, nil
 }
 
 // ConsultarCobrancaImediata consulta uma cobrança imediata.
 func (c *Service) ConsultarCobrancaImediata(ctx context.Context, txID string) (*CobrancaImediataResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &CobrancaImediataResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.ConsultarCobrancaImediata",
+		Method:     resty.MethodGet,
+		Endpoint:   path.Join(pixEndpoint, "cob", "{txid}"),
+		PathParams: map[string]string{"txid": txID},
+		Scopes:     []auth.Scope{auth.ScopeCobRead},
+		Result:     result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&CobrancaImediataResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	resp, err := req.Get(path.Join(pixEndpoint, "cob", txID))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*CobrancaImediataResponse), nil
+	return result, nil
 }
 
 // CriarCobrancaImediataComTxID cria uma cobrança imediata com o txID informado.
+// Se o txid já existir com a mesma chave, valor e devedor (por exemplo, na repetição após um timeout),
+// a cobrança existente é retornada; se pertencer a outra cobrança, retorna ErrTxIDEmUso.
 func (c *Service) CriarCobrancaImediataComTxID(ctx context.Context, txID string, request *CobrancaImediataRequest) (*CobrancaImediataResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	if err := ValidarTxID(txID); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&CobrancaImediataResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken()).
-		SetHeader("Content-Type", "application/json").
-		SetBody(request)
-
-	resp, err := req.Put(path.Join(pixEndpoint, "cob", txID))
+	request, err := cobrancaImediataComChave(request, false)
 	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+		return nil, err
+	}
+
+	if err := ValidarRetirada(request.Valor); err != nil {
+		return nil, err
 	}
 
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
+	result := &CobrancaImediataResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.CriarCobrancaImediataComTxID",
+		Method:     resty.MethodPut,
+		Endpoint:   path.Join(pixEndpoint, "cob", "{txid}"),
+		PathParams: map[string]string{"txid": txID},
+		Scopes:     []auth.Scope{auth.ScopeCobWrite},
+		Idempotent: true,
+		Body:       request,
+		Result:     result,
+	}); err != nil {
+		if jaExiste(err) {
+			return c.cobrancaImediataExistente(ctx, txID, request, err)
 		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+		return nil, err
 	}
 
-	return resp.Result().(*CobrancaImediataResponse), nil
+	return result, nil
 }
 
 // CriarCobrancaImediata cria uma cobrança imediata.
 func (c *Service) CriarCobrancaImediata(ctx context.Context, request *CobrancaImediataRequest) (*CobrancaImediataResponse, error) {
-	token, err := c.backend.Token(ctx)
+	request, err := cobrancaImediataComChave(request, false)
 	if err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&CobrancaImediataResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken()).
-		SetHeader("Content-Type", "application/json").
-		SetBody(request)
-
-	resp, err := req.Post(path.Join(pixEndpoint, "cob"))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+	if err := ValidarRetirada(request.Valor); err != nil {
+		return nil, err
 	}
 
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+	result := &CobrancaImediataResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "pix.CriarCobrancaImediata",
+		Method:    resty.MethodPost,
+		Endpoint:  path.Join(pixEndpoint, "cob"),
+		Scopes:    []auth.Scope{auth.ScopeCobWrite},
+		Body:      request,
+		Result:    result,
+	}); err != nil {
+		return nil, err
 	}
 
-	return resp.Result().(*CobrancaImediataResponse), nil
+	return result, nil
 }
@@ -54,12 +54,12 @@ type InfoAdicional struct {
 }
 
 type CobrancaImediataRequest struct {
-	Calendario         *CalendarioSemVencimento `json:"calendario"`               // Expiração
-	Devedor            *Identificador           `json:"devedor"`                  // Devedor
-	Valor              *ValorCobranca           `json:"valor"`                    // Valor da cobrança
-	Chave              string                   `json:"chave"`                    // Chave Pix do recebedor
-	InfoAdicionais     []*InfoAdicional         `json:"infoAdicionais,omitempty"` // Cada respectiva informação adicional contida na lista (nome e valor) deve ser apresentada ao pagador.
-	SolicitacaoPagador string                   `json:"solicitacaoPagador"`       // O campo solicitacaoPagador determina um texto a ser apresentado ao pagador para que ele possa digitar uma informação correlata, em formato livre, a ser enviada ao recebedor. Esse texto está limitado a 140 caracteres.
+	Calendario         *CalendarioSemVencimento `json:"calendario,omitempty"`         // Expiração
+	Devedor            *Identificador           `json:"devedor,omitempty"`            // Devedor
+	Valor              *ValorCobranca           `json:"valor,omitempty"`              // Valor da cobrança
+	Chave              string                   `json:"chave,omitempty"`              // Chave Pix do recebedor
+	InfoAdicionais     []*InfoAdicional         `json:"infoAdicionais,omitempty"`     // Cada respectiva informação adicional contida na lista (nome e valor) deve ser apresentada ao pagador.
+	SolicitacaoPagador string                   `json:"solicitacaoPagador,omitempty"` // O campo solicitacaoPagador determina um texto a ser apresentado ao pagador para que ele possa digitar uma informação correlata, em formato livre, a ser enviada ao recebedor. Esse texto está limitado a 140 caracteres.
 
 	Loc    *Loc           `json:"loc,omitempty"`    // Identificador da localização do payload
 	Status CobrancaStatus `json:"status,omitempty"` // Status da Cobrança
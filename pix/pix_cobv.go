@@ -4,134 +4,102 @@ import (
 	"context"
 	"path"
 
-	"github.com/raniellyferreira/interbank-go/erros"
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
+	"github.com/raniellyferreira/interbank-go/backend"
 	interutils "github.com/raniellyferreira/interbank-go/utils"
 )
 
-// CriarCobrancaComVencimentoETxID - Cria uma cobrança imediata com vencimento e txID
+// CriarCobrancaComVencimentoETxID - Cria uma cobrança imediata com vencimento e txID.
+// Se o txid já existir com a mesma chave, valor e devedor, a cobrança existente é retornada;
+// se pertencer a outra cobrança, retorna ErrTxIDEmUso.
 func (c *Service) CriarCobrancaComVencimentoETxID(ctx context.Context, txID string, request *CobrancaComVencimentoRequest) (*CobrancaComVencimentoResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	if err := ValidarTxID(txID); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&CobrancaComVencimentoResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken()).
-		SetHeader("Content-Type", "application/json").
-		SetBody(request)
-
-	resp, err := req.Put(path.Join(pixEndpoint, "cobv", txID))
+	request, err := cobrancaComVencimentoComChave(request, false)
 	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+		return nil, err
 	}
 
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
+	result := &CobrancaComVencimentoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.CriarCobrancaComVencimentoETxID",
+		Method:     resty.MethodPut,
+		Endpoint:   path.Join(pixEndpoint, "cobv", "{txid}"),
+		PathParams: map[string]string{"txid": txID},
+		Scopes:     []auth.Scope{auth.ScopeCobVWrite},
+		Idempotent: true,
+		Body:       request,
+		Result:     result,
+	}); err != nil {
+		if jaExiste(err) {
+			return c.cobrancaComVencimentoExistente(ctx, txID, request, err)
 		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+		return nil, err
 	}
 
-	return resp.Result().(*CobrancaComVencimentoResponse), nil
+	return result, nil
 }
 
 // ConsultarCobrancasComVencimento - Consulta cobranças imediatas com vencimento
 func (c *Service) ConsultarCobrancasComVencimento(ctx context.Context, request *ConsultarCobrancasComVencimentoRequest) (*ConsultarCobrancasComVencimentoResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &ConsultarCobrancasComVencimentoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "pix.ConsultarCobrancasComVencimento",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(pixEndpoint, "cobv"),
+		Query:     interutils.StructToMap(request),
+		Scopes:    []auth.Scope{auth.ScopeCobVRead},
+		Result:    result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&ConsultarCobrancasComVencimentoResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	if request != nil {
-		req.SetQueryParams(interutils.StructToMap(request))
-	}
-
-	resp, err := req.Get(path.Join(pixEndpoint, "cobv"))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*ConsultarCobrancasComVencimentoResponse), nil
+	return result, nil
 }
 
 // ConsultarCobrancaComVencimento - Consulta uma cobrança com vencimento
 func (c *Service) ConsultarCobrancaComVencimento(ctx context.Context, txID string) (*CobrancaComVencimentoResponse, error) {
-	token, err := c.backend.Token(ctx)
-	if err != nil {
+	result := &CobrancaComVencimentoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.ConsultarCobrancaComVencimento",
+		Method:     resty.MethodGet,
+		Endpoint:   path.Join(pixEndpoint, "cobv", "{txid}"),
+		PathParams: map[string]string{"txid": txID},
+		Scopes:     []auth.Scope{auth.ScopeCobVRead},
+		Result:     result,
+	}); err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&CobrancaComVencimentoResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken())
-
-	resp, err := req.Get(path.Join(pixEndpoint, "cobv", txID))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	return resp.Result().(*CobrancaComVencimentoResponse), nil
+	return result, nil
 }
 
 // EditarCobrancaComVencimento - Edita uma cobrança com vencimento e txID
 func (c *Service) EditarCobrancaComVencimento(ctx context.Context, txID string, request *CobrancaComVencimentoRequest) (*CobrancaComVencimentoResponse, error) {
-	token, err := c.backend.Token(ctx)
+	request, err := cobrancaComVencimentoComChave(request, true)
 	if err != nil {
 		return nil, err
 	}
 
-	req := c.backend.Req().
-		SetContext(ctx).
-		SetResult(&CobrancaComVencimentoResponse{}).
-		SetError(&erros.Response{}).
-		SetAuthToken(token.GetAccessToken()).
-		SetHeader("Content-Type", "application/json").
-		SetBody(request)
-
-	resp, err := req.Patch(path.Join(pixEndpoint, "cobv", txID))
-	if err != nil {
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
-	}
-
-	// Check for errors
-	if resp.IsError() {
-		errResp, ok := resp.Error().(*erros.Response)
-		if ok {
-			return nil, errResp.WithStatus(resp.StatusCode())
-		}
-		return nil, erros.NewErrorWithStatus(resp.StatusCode(), resp.String())
+	result := &CobrancaComVencimentoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.EditarCobrancaComVencimento",
+		Method:     resty.MethodPatch,
+		Endpoint:   path.Join(pixEndpoint, "cobv", "{txid}"),
+		PathParams: map[string]string{"txid": txID},
+		Scopes:     []auth.Scope{auth.ScopeCobVWrite},
+		Body:       request,
+		Result:     result,
+	}); err != nil {
+		return nil, err
 	}
 
-	return resp.Result().(*CobrancaComVencimentoResponse), nil
+	return result, nil
 }
@@ -0,0 +1,393 @@
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/raniellyferreira/interbank-go/calendario"
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+const (
+	// DefaultValidadeAposVencimento é a validade após o vencimento assumida pelo Bacen quando o campo não é informado
+	DefaultValidadeAposVencimento = 30
+
+	diasMesCorridos = 30  // Mês comercial para juros percentuais em dias corridos
+	diasAnoCorridos = 365 // Ano civil para juros percentuais em dias corridos
+	diasMesUteis    = 21  // Dias úteis em um mês para juros percentuais em dias úteis
+	diasAnoUteis    = 252 // Dias úteis em um ano para juros percentuais em dias úteis
+
+	dateLayout = "2006-01-02"
+)
+
+var (
+	// ErrCobrancaExpirada é retornado quando a data de pagamento é posterior à validade da cobrança
+	ErrCobrancaExpirada = errors.New("cobrança com vencimento expirada")
+
+	// ErrCalculoInvalido é retornado quando os dados da cobrança não permitem o cálculo
+	ErrCalculoInvalido = errors.New("cálculo de cobrança com vencimento inválido")
+)
+
+// CalculoCobrancaComVencimento é o detalhamento do valor a pagar de uma cobrança com vencimento em uma data.
+// Os valores estão em centavos.
+type CalculoCobrancaComVencimento struct {
+	DataPagamento   time.Time // Data de pagamento considerada
+	DiasAtraso      int       // Dias de atraso considerados no cálculo dos juros
+	DiasAntecipacao int       // Dias de antecipação considerados no cálculo do desconto
+
+	Original   int64 // Valor original
+	Multa      int64 // Valor da multa
+	Juros      int64 // Valor dos juros
+	Abatimento int64 // Valor do abatimento
+	Desconto   int64 // Valor do desconto
+	Total      int64 // Valor a pagar: original + multa + juros - abatimento - desconto
+}
+
+// Valor retorna o valor total formatado como na API (ex: "10.50")
+func (c *CalculoCobrancaComVencimento) Valor() string {
+	return interutils.FormatCentavos(c.Total)
+}
+
+// ComponentesValor retorna o detalhamento no mesmo formato do Pix recebido
+func (c *CalculoCobrancaComVencimento) ComponentesValor() *ComponentesValor {
+	componente := func(valor int64) *ComponenteValor {
+		if valor == 0 {
+			return nil
+		}
+		return &ComponenteValor{Valor: interutils.FormatCentavos(valor)}
+	}
+
+	return &ComponentesValor{
+		Original:   &ComponenteValor{Valor: interutils.FormatCentavos(c.Original)},
+		Multa:      componente(c.Multa),
+		Juros:      componente(c.Juros),
+		Abatimento: componente(c.Abatimento),
+		Desconto:   componente(c.Desconto),
+	}
+}
+
+// Conferir compara o cálculo com um Pix recebido e retorna um erro descrevendo as divergências
+func (c *CalculoCobrancaComVencimento) Conferir(pix *Pix) error {
+	valor, err := interutils.ParseCentavos(pix.Valor)
+	if err != nil {
+		return err
+	}
+
+	var divergencias []string
+	if valor != c.Total {
+		divergencias = append(divergencias, fmt.Sprintf("valor %s != %s", pix.Valor, c.Valor()))
+	}
+
+	if pix.ComponentesValor != nil {
+		esperado := map[string]int64{
+			"original":   c.Original,
+			"multa":      c.Multa,
+			"juros":      c.Juros,
+			"abatimento": c.Abatimento,
+			"desconto":   c.Desconto,
+		}
+		recebido := map[string]*ComponenteValor{
+			"original":   pix.ComponentesValor.Original,
+			"multa":      pix.ComponentesValor.Multa,
+			"juros":      pix.ComponentesValor.Juros,
+			"abatimento": pix.ComponentesValor.Abatimento,
+			"desconto":   pix.ComponentesValor.Desconto,
+		}
+
+		nomes := make([]string, 0, len(esperado))
+		for nome := range esperado {
+			nomes = append(nomes, nome)
+		}
+		sort.Strings(nomes)
+
+		for _, nome := range nomes {
+			var valorRecebido int64
+			if recebido[nome] != nil {
+				valorRecebido, err = interutils.ParseCentavos(recebido[nome].Valor)
+				if err != nil {
+					return err
+				}
+			}
+			if valorRecebido != esperado[nome] {
+				divergencias = append(divergencias, fmt.Sprintf("%s %s != %s",
+					nome, interutils.FormatCentavos(valorRecebido), interutils.FormatCentavos(esperado[nome])))
+			}
+		}
+	}
+
+	if len(divergencias) > 0 {
+		return fmt.Errorf("pix %s does not match the expected amount: %v", pix.EndToEndID, divergencias)
+	}
+	return nil
+}
+
+// CalculadoraCobrancaComVencimento calcula o valor a pagar de cobranças com vencimento seguindo as regras do Bacen
+type CalculadoraCobrancaComVencimento struct {
+	calendario *calendario.Calendario
+}
+
+// NewCalculadoraCobrancaComVencimento creates a new calculator using the national banking calendar (calendario.Padrao)
+func NewCalculadoraCobrancaComVencimento() *CalculadoraCobrancaComVencimento {
+	return &CalculadoraCobrancaComVencimento{
+		calendario: calendario.Padrao,
+	}
+}
+
+// SetCalendario sets the calendar used to count business days (e.g. with municipal holidays)
+func (c *CalculadoraCobrancaComVencimento) SetCalendario(cal *calendario.Calendario) *CalculadoraCobrancaComVencimento {
+	c.calendario = cal
+	return c
+}
+
+// Calcular retorna o detalhamento do valor da cobrança para pagamento na data informada.
+//
+// Regras aplicadas:
+//   - Abatimento: aplicado em qualquer data de pagamento;
+//   - Desconto: aplicado somente em pagamentos até o vencimento;
+//   - Multa e juros: aplicados somente em pagamentos após o vencimento. Quando o vencimento cai em
+//     dia não útil, o pagamento no dia útil seguinte não sofre encargos;
+//   - Juros percentuais ao mês/ano usam 30/365 dias corridos ou 21/252 dias úteis;
+//   - Pagamentos após o vencimento + validadeAposVencimento retornam ErrCobrancaExpirada.
+func (c *CalculadoraCobrancaComVencimento) Calcular(calendario *CalendarioComVencimento, valor *ValorCobrancaComVencimento, dataPagamento time.Time) (*CalculoCobrancaComVencimento, error) {
+	if calendario == nil || valor == nil {
+		return nil, fmt.Errorf("%w: calendario and valor are required", ErrCalculoInvalido)
+	}
+
+	vencimento, err := time.Parse(dateLayout, calendario.DataDeVencimento)
+	if err != nil {
+		return nil, fmt.Errorf("%w: dataDeVencimento: %v", ErrCalculoInvalido, err)
+	}
+
+	original, err := parseDecimal(valor.Original)
+	if err != nil {
+		return nil, err
+	}
+
+	pagamento := truncarData(dataPagamento)
+	calculo := &CalculoCobrancaComVencimento{
+		DataPagamento: pagamento,
+		Original:      centavos(original),
+	}
+
+	validade := int(calendario.ValidadeAposVencimento)
+	if validade == 0 {
+		validade = DefaultValidadeAposVencimento
+	}
+	if pagamento.After(vencimento.AddDate(0, 0, validade)) {
+		return nil, fmt.Errorf("%w: vencimento %s, validade %d dias", ErrCobrancaExpirada, calendario.DataDeVencimento, validade)
+	}
+
+	// Abatimento
+	if valor.Abatimento != nil {
+		abatimento, err := parseDecimal(valor.Abatimento.ValorPerc)
+		if err != nil {
+			return nil, err
+		}
+		switch valor.Abatimento.Modalidade {
+		case ModalidadeAbatimentoValorFixo:
+		case ModalidadeAbatimentoPercentual:
+			abatimento = percentual(original, abatimento)
+		default:
+			return nil, fmt.Errorf("%w: unknown abatimento modalidade %d", ErrCalculoInvalido, valor.Abatimento.Modalidade)
+		}
+		calculo.Abatimento = centavos(abatimento)
+	}
+
+	if !pagamento.After(vencimento) {
+		if err := c.calcularDesconto(calculo, valor.Desconto, original, vencimento, pagamento); err != nil {
+			return nil, err
+		}
+	} else if pagamento.After(c.proximoDiaUtil(vencimento)) {
+		if err := c.calcularEncargos(calculo, valor, original, vencimento, pagamento); err != nil {
+			return nil, err
+		}
+	}
+
+	// O desconto não pode ser maior que o valor restante após o abatimento
+	if limite := calculo.Original - calculo.Abatimento; calculo.Desconto > limite {
+		calculo.Desconto = max(limite, 0)
+	}
+
+	calculo.Total = calculo.Original + calculo.Multa + calculo.Juros - calculo.Abatimento - calculo.Desconto
+	if calculo.Total < 0 {
+		calculo.Total = 0
+	}
+
+	return calculo, nil
+}
+
+// calcularDesconto applies the early payment discount
+func (c *CalculadoraCobrancaComVencimento) calcularDesconto(calculo *CalculoCobrancaComVencimento, desconto *ComponenteValorDesconto, original *big.Rat, vencimento, pagamento time.Time) error {
+	if desconto == nil {
+		return nil
+	}
+
+	switch desconto.Modalidade {
+	case ModalidadeDescontoValorFixoAteDataInformada, ModalidadeDescontoPercentualAteDataInformada:
+		datas := make([]*DescontoDataFixa, 0, len(desconto.DescontoDataFixa))
+		for _, d := range desconto.DescontoDataFixa {
+			if d != nil {
+				datas = append(datas, d)
+			}
+		}
+		sort.Slice(datas, func(i, j int) bool { return datas[i].Data < datas[j].Data })
+
+		// Vale o desconto da primeira data ainda não ultrapassada
+		for _, d := range datas {
+			data, err := time.Parse(dateLayout, d.Data)
+			if err != nil {
+				return fmt.Errorf("%w: descontoDataFixa: %v", ErrCalculoInvalido, err)
+			}
+			if pagamento.After(data) {
+				continue
+			}
+
+			valorDesconto, err := parseDecimal(d.ValorPerc)
+			if err != nil {
+				return err
+			}
+			if desconto.Modalidade == ModalidadeDescontoPercentualAteDataInformada {
+				valorDesconto = percentual(original, valorDesconto)
+			}
+			calculo.Desconto = centavos(valorDesconto)
+			return nil
+		}
+		return nil
+
+	case ModalidadeDescontoValorPorAntecipacaoDiaCorrido, ModalidadeDescontoPercentualPorAntecipacaoDiaCorrido,
+		ModalidadeDescontoValorPorAntecipacaoDiaUtil, ModalidadeDescontoPercentualPorAntecipacaoDiaUtil:
+		valorPerc, err := parseDecimal(desconto.ValorPerc)
+		if err != nil {
+			return err
+		}
+
+		dias := diasCorridos(pagamento, vencimento)
+		if desconto.Modalidade == ModalidadeDescontoValorPorAntecipacaoDiaUtil || desconto.Modalidade == ModalidadeDescontoPercentualPorAntecipacaoDiaUtil {
+			dias = c.diasUteis(pagamento, vencimento)
+		}
+		calculo.DiasAntecipacao = dias
+
+		if desconto.Modalidade == ModalidadeDescontoPercentualPorAntecipacaoDiaCorrido || desconto.Modalidade == ModalidadeDescontoPercentualPorAntecipacaoDiaUtil {
+			valorPerc = percentual(original, valorPerc)
+		}
+		calculo.Desconto = centavos(new(big.Rat).Mul(valorPerc, big.NewRat(int64(dias), 1)))
+		return nil
+	}
+
+	return fmt.Errorf("%w: unknown desconto modalidade %q", ErrCalculoInvalido, desconto.Modalidade)
+}
+
+// calcularEncargos applies the late payment penalty and interest
+func (c *CalculadoraCobrancaComVencimento) calcularEncargos(calculo *CalculoCobrancaComVencimento, valor *ValorCobrancaComVencimento, original *big.Rat, vencimento, pagamento time.Time) error {
+	if valor.Multa != nil {
+		multa, err := parseDecimal(valor.Multa.ValorPerc)
+		if err != nil {
+			return err
+		}
+		switch valor.Multa.Modalidade {
+		case ModalidadeMultaValorFixo:
+		case ModalidadeMultaPercentual:
+			multa = percentual(original, multa)
+		default:
+			return fmt.Errorf("%w: unknown multa modalidade %d", ErrCalculoInvalido, valor.Multa.Modalidade)
+		}
+		calculo.Multa = centavos(multa)
+	}
+
+	if valor.Juros == nil {
+		return nil
+	}
+
+	juros, err := parseDecimal(valor.Juros.ValorPerc)
+	if err != nil {
+		return err
+	}
+
+	var dias int
+	var divisor int64
+	switch valor.Juros.Modalidade {
+	case ModalidadeJurosValorDiasCorridos, ModalidadeJurosPercentualDia:
+		dias, divisor = diasCorridos(vencimento, pagamento), 1
+	case ModalidadeJurosPercentualMes:
+		dias, divisor = diasCorridos(vencimento, pagamento), diasMesCorridos
+	case ModalidadeJurosPercentualAno:
+		dias, divisor = diasCorridos(vencimento, pagamento), diasAnoCorridos
+	case ModalidadeJurosValorDiasUteis, ModalidadeJurosPercentualDiaUteis:
+		dias, divisor = c.diasUteis(vencimento, pagamento), 1
+	case ModalidadeJurosPercentualMesUteis:
+		dias, divisor = c.diasUteis(vencimento, pagamento), diasMesUteis
+	case ModalidadeJurosPercentualAnoUteis:
+		dias, divisor = c.diasUteis(vencimento, pagamento), diasAnoUteis
+	default:
+		return fmt.Errorf("%w: unknown juros modalidade %d", ErrCalculoInvalido, valor.Juros.Modalidade)
+	}
+	calculo.DiasAtraso = dias
+
+	if valor.Juros.Modalidade != ModalidadeJurosValorDiasCorridos && valor.Juros.Modalidade != ModalidadeJurosValorDiasUteis {
+		juros = percentual(original, juros)
+	}
+
+	juros.Mul(juros, big.NewRat(int64(dias), divisor))
+	calculo.Juros = centavos(juros)
+	return nil
+}
+
+// proximoDiaUtil returns the date itself if it is a business day or the next business day
+func (c *CalculadoraCobrancaComVencimento) proximoDiaUtil(data time.Time) time.Time {
+	return c.calendario.AjustarDiaUtil(data)
+}
+
+// diasUteis counts the business days in the interval (inicio, fim]
+func (c *CalculadoraCobrancaComVencimento) diasUteis(inicio, fim time.Time) int {
+	return c.calendario.DiasUteisEntre(inicio, fim)
+}
+
+// Calcular calcula o valor a pagar da cobrança na data informada (ver CalculadoraCobrancaComVencimento.Calcular)
+func (r *CobrancaComVencimentoRequest) Calcular(dataPagamento time.Time) (*CalculoCobrancaComVencimento, error) {
+	return NewCalculadoraCobrancaComVencimento().Calcular(r.Calendario, r.Valor, dataPagamento)
+}
+
+// Calcular calcula o valor a pagar da cobrança na data informada (ver CalculadoraCobrancaComVencimento.Calcular)
+func (r *CobrancaComVencimentoResponse) Calcular(dataPagamento time.Time) (*CalculoCobrancaComVencimento, error) {
+	return NewCalculadoraCobrancaComVencimento().Calcular(r.Calendario, r.Valor, dataPagamento)
+}
+
+// diasCorridos returns the number of calendar days from inicio to fim
+func diasCorridos(inicio, fim time.Time) int {
+	return int(fim.Sub(inicio).Hours() / 24)
+}
+
+// truncarData keeps only the date of t, in UTC
+func truncarData(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// parseDecimal parses a decimal amount or percentage (e.g. "10.50")
+func parseDecimal(valor string) (*big.Rat, error) {
+	if valor == "" {
+		return new(big.Rat), nil
+	}
+
+	r, ok := new(big.Rat).SetString(valor)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid decimal %q", ErrCalculoInvalido, valor)
+	}
+	return r, nil
+}
+
+// percentual returns base * perc / 100
+func percentual(base, perc *big.Rat) *big.Rat {
+	r := new(big.Rat).Mul(base, perc)
+	return r.Quo(r, big.NewRat(100, 1))
+}
+
+// centavos rounds the amount to cents (half up)
+func centavos(valor *big.Rat) int64 {
+	r := new(big.Rat).Mul(valor, big.NewRat(100, 1))
+	r.Add(r, big.NewRat(1, 2))
+	return new(big.Int).Div(r.Num(), r.Denom()).Int64()
+}
@@ -0,0 +1,267 @@
+package pix
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCalcularCobrancaComVencimento(t *testing.T) {
+	// Vencimento em 2024-11-14 (quinta-feira). Até 2024-11-22 são 8 dias corridos e 4 dias úteis
+	// (15 e 20 são feriados, 16 e 17 fim de semana). Entre 2024-11-08 e o vencimento são 6 dias
+	// corridos e 4 dias úteis.
+	const (
+		vencimento = "2024-11-14"
+		original   = "1000.00"
+	)
+	atraso := time.Date(2024, time.November, 22, 0, 0, 0, 0, time.UTC)
+	antecipado := time.Date(2024, time.November, 8, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		vencimento string
+		validade   int32
+		valor      ValorCobrancaComVencimento
+		pagamento  time.Time
+
+		multa, juros, abatimento, desconto, total int64
+		err                                       error
+	}{
+		{
+			name:      "no vencimento",
+			valor:     ValorCobrancaComVencimento{Original: original},
+			pagamento: time.Date(2024, time.November, 14, 23, 59, 0, 0, time.UTC),
+			total:     100000,
+		},
+		{
+			name:      "multa valor fixo",
+			valor:     ValorCobrancaComVencimento{Original: original, Multa: &ComponenteValorMulta{Modalidade: ModalidadeMultaValorFixo, ValorPerc: "10.00"}},
+			pagamento: atraso,
+			multa:     1000,
+			total:     101000,
+		},
+		{
+			name:      "multa percentual",
+			valor:     ValorCobrancaComVencimento{Original: original, Multa: &ComponenteValorMulta{Modalidade: ModalidadeMultaPercentual, ValorPerc: "2.00"}},
+			pagamento: atraso,
+			multa:     2000,
+			total:     102000,
+		},
+		{
+			name:      "juros valor dias corridos",
+			valor:     ValorCobrancaComVencimento{Original: original, Juros: &ComponenteValorJuros{Modalidade: ModalidadeJurosValorDiasCorridos, ValorPerc: "1.50"}},
+			pagamento: atraso,
+			juros:     1200, // 1,50 * 8
+			total:     101200,
+		},
+		{
+			name:      "juros percentual ao dia",
+			valor:     ValorCobrancaComVencimento{Original: original, Juros: &ComponenteValorJuros{Modalidade: ModalidadeJurosPercentualDia, ValorPerc: "0.10"}},
+			pagamento: atraso,
+			juros:     800, // 1,00 * 8
+			total:     100800,
+		},
+		{
+			name:      "juros percentual ao mês",
+			valor:     ValorCobrancaComVencimento{Original: original, Juros: &ComponenteValorJuros{Modalidade: ModalidadeJurosPercentualMes, ValorPerc: "3.00"}},
+			pagamento: atraso,
+			juros:     800, // 30,00 * 8 / 30
+			total:     100800,
+		},
+		{
+			name:      "juros percentual ao mês com arredondamento",
+			valor:     ValorCobrancaComVencimento{Original: original, Juros: &ComponenteValorJuros{Modalidade: ModalidadeJurosPercentualMes, ValorPerc: "1.00"}},
+			pagamento: atraso,
+			juros:     267, // 10,00 * 8 / 30 = 2,666...
+			total:     100267,
+		},
+		{
+			name:      "juros percentual ao ano",
+			valor:     ValorCobrancaComVencimento{Original: original, Juros: &ComponenteValorJuros{Modalidade: ModalidadeJurosPercentualAno, ValorPerc: "36.50"}},
+			pagamento: atraso,
+			juros:     800, // 365,00 * 8 / 365
+			total:     100800,
+		},
+		{
+			name:      "juros valor dias úteis",
+			valor:     ValorCobrancaComVencimento{Original: original, Juros: &ComponenteValorJuros{Modalidade: ModalidadeJurosValorDiasUteis, ValorPerc: "1.50"}},
+			pagamento: atraso,
+			juros:     600, // 1,50 * 4
+			total:     100600,
+		},
+		{
+			name:      "juros percentual ao dia útil",
+			valor:     ValorCobrancaComVencimento{Original: original, Juros: &ComponenteValorJuros{Modalidade: ModalidadeJurosPercentualDiaUteis, ValorPerc: "0.10"}},
+			pagamento: atraso,
+			juros:     400, // 1,00 * 4
+			total:     100400,
+		},
+		{
+			name:      "juros percentual ao mês em dias úteis",
+			valor:     ValorCobrancaComVencimento{Original: original, Juros: &ComponenteValorJuros{Modalidade: ModalidadeJurosPercentualMesUteis, ValorPerc: "2.10"}},
+			pagamento: atraso,
+			juros:     400, // 21,00 * 4 / 21
+			total:     100400,
+		},
+		{
+			name:      "juros percentual ao ano em dias úteis",
+			valor:     ValorCobrancaComVencimento{Original: original, Juros: &ComponenteValorJuros{Modalidade: ModalidadeJurosPercentualAnoUteis, ValorPerc: "25.20"}},
+			pagamento: atraso,
+			juros:     400, // 252,00 * 4 / 252
+			total:     100400,
+		},
+		{
+			name:       "abatimento valor fixo",
+			valor:      ValorCobrancaComVencimento{Original: original, Abatimento: &ComponenteValorAbatimento{Modalidade: ModalidadeAbatimentoValorFixo, ValorPerc: "50.00"}},
+			pagamento:  antecipado,
+			abatimento: 5000,
+			total:      95000,
+		},
+		{
+			name:       "abatimento percentual",
+			valor:      ValorCobrancaComVencimento{Original: original, Abatimento: &ComponenteValorAbatimento{Modalidade: ModalidadeAbatimentoPercentual, ValorPerc: "5"}},
+			pagamento:  atraso,
+			abatimento: 5000,
+			total:      95000,
+		},
+		{
+			name: "desconto valor fixo até a data",
+			valor: ValorCobrancaComVencimento{Original: original, Desconto: &ComponenteValorDesconto{
+				Modalidade: ModalidadeDescontoValorFixoAteDataInformada,
+				DescontoDataFixa: []*DescontoDataFixa{
+					{Data: "2024-11-10", ValorPerc: "20.00"},
+					{Data: "2024-11-05", ValorPerc: "30.00"},
+				},
+			}},
+			pagamento: antecipado,
+			desconto:  2000,
+			total:     98000,
+		},
+		{
+			name: "desconto percentual até a data",
+			valor: ValorCobrancaComVencimento{Original: original, Desconto: &ComponenteValorDesconto{
+				Modalidade: ModalidadeDescontoPercentualAteDataInformada,
+				DescontoDataFixa: []*DescontoDataFixa{
+					{Data: "2024-11-05", ValorPerc: "3.00"},
+					{Data: "2024-11-10", ValorPerc: "2.00"},
+				},
+			}},
+			pagamento: antecipado,
+			desconto:  2000,
+			total:     98000,
+		},
+		{
+			name:      "desconto valor por antecipação em dias corridos",
+			valor:     ValorCobrancaComVencimento{Original: original, Desconto: &ComponenteValorDesconto{Modalidade: ModalidadeDescontoValorPorAntecipacaoDiaCorrido, ValorPerc: "1.00"}},
+			pagamento: antecipado,
+			desconto:  600, // 1,00 * 6
+			total:     99400,
+		},
+		{
+			name:      "desconto valor por antecipação em dias úteis",
+			valor:     ValorCobrancaComVencimento{Original: original, Desconto: &ComponenteValorDesconto{Modalidade: ModalidadeDescontoValorPorAntecipacaoDiaUtil, ValorPerc: "1.00"}},
+			pagamento: antecipado,
+			desconto:  400, // 1,00 * 4
+			total:     99600,
+		},
+		{
+			name:      "desconto percentual por antecipação em dias corridos",
+			valor:     ValorCobrancaComVencimento{Original: original, Desconto: &ComponenteValorDesconto{Modalidade: ModalidadeDescontoPercentualPorAntecipacaoDiaCorrido, ValorPerc: "0.10"}},
+			pagamento: antecipado,
+			desconto:  600, // 1,00 * 6
+			total:     99400,
+		},
+		{
+			name:      "desconto percentual por antecipação em dias úteis",
+			valor:     ValorCobrancaComVencimento{Original: original, Desconto: &ComponenteValorDesconto{Modalidade: ModalidadeDescontoPercentualPorAntecipacaoDiaUtil, ValorPerc: "0.10"}},
+			pagamento: antecipado,
+			desconto:  400, // 1,00 * 4
+			total:     99600,
+		},
+		{
+			name:      "desconto não aplicado após o vencimento",
+			valor:     ValorCobrancaComVencimento{Original: original, Desconto: &ComponenteValorDesconto{Modalidade: ModalidadeDescontoValorPorAntecipacaoDiaCorrido, ValorPerc: "1.00"}},
+			pagamento: atraso,
+			total:     100000,
+		},
+		{
+			name: "multa, juros e abatimento",
+			valor: ValorCobrancaComVencimento{
+				Original:   original,
+				Multa:      &ComponenteValorMulta{Modalidade: ModalidadeMultaPercentual, ValorPerc: "2.00"},
+				Juros:      &ComponenteValorJuros{Modalidade: ModalidadeJurosPercentualMes, ValorPerc: "1.00"},
+				Abatimento: &ComponenteValorAbatimento{Modalidade: ModalidadeAbatimentoValorFixo, ValorPerc: "50.00"},
+			},
+			pagamento:  atraso,
+			multa:      2000,
+			juros:      267,
+			abatimento: 5000,
+			total:      97267,
+		},
+		{
+			name:       "vencimento em feriado pago no dia útil seguinte",
+			vencimento: "2024-11-15",
+			valor: ValorCobrancaComVencimento{
+				Original: original,
+				Multa:    &ComponenteValorMulta{Modalidade: ModalidadeMultaPercentual, ValorPerc: "2.00"},
+				Juros:    &ComponenteValorJuros{Modalidade: ModalidadeJurosPercentualDia, ValorPerc: "0.10"},
+			},
+			pagamento: time.Date(2024, time.November, 18, 0, 0, 0, 0, time.UTC),
+			total:     100000,
+		},
+		{
+			name:       "vencimento em feriado pago após o dia útil seguinte",
+			vencimento: "2024-11-15",
+			valor: ValorCobrancaComVencimento{
+				Original: original,
+				Multa:    &ComponenteValorMulta{Modalidade: ModalidadeMultaPercentual, ValorPerc: "2.00"},
+				Juros:    &ComponenteValorJuros{Modalidade: ModalidadeJurosPercentualDia, ValorPerc: "0.10"},
+			},
+			pagamento: time.Date(2024, time.November, 19, 0, 0, 0, 0, time.UTC),
+			multa:     2000,
+			juros:     400, // 1,00 * 4 dias corridos desde o vencimento
+			total:     102400,
+		},
+		{
+			name:      "expirada",
+			validade:  5,
+			valor:     ValorCobrancaComVencimento{Original: original},
+			pagamento: time.Date(2024, time.November, 20, 0, 0, 0, 0, time.UTC),
+			err:       ErrCobrancaExpirada,
+		},
+		{
+			name:      "modalidade desconhecida",
+			valor:     ValorCobrancaComVencimento{Original: original, Juros: &ComponenteValorJuros{Modalidade: 9, ValorPerc: "1.00"}},
+			pagamento: atraso,
+			err:       ErrCalculoInvalido,
+		},
+	}
+
+	calculadora := NewCalculadoraCobrancaComVencimento()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := &CalendarioComVencimento{DataDeVencimento: vencimento, ValidadeAposVencimento: tt.validade}
+			if tt.vencimento != "" {
+				cal.DataDeVencimento = tt.vencimento
+			}
+
+			calculo, err := calculadora.Calcular(cal, &tt.valor, tt.pagamento)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Calcular() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Calcular() unexpected error: %v", err)
+			}
+
+			if calculo.Multa != tt.multa || calculo.Juros != tt.juros || calculo.Abatimento != tt.abatimento ||
+				calculo.Desconto != tt.desconto || calculo.Total != tt.total {
+				t.Errorf("Calcular() = multa %d juros %d abatimento %d desconto %d total %d, want %d %d %d %d %d",
+					calculo.Multa, calculo.Juros, calculo.Abatimento, calculo.Desconto, calculo.Total,
+					tt.multa, tt.juros, tt.abatimento, tt.desconto, tt.total)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package pix
+
+import (
+	"context"
+	"path"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
+	"github.com/raniellyferreira/interbank-go/backend"
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+// CriarLoc cria uma location do payload para uma cobrança do tipo informado (cob ou cobv)
+func (c *Service) CriarLoc(ctx context.Context, tipoCob TipoCobranca) (*LocResponse, error) {
+	result := &LocResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "pix.CriarLoc",
+		Method:    resty.MethodPost,
+		Endpoint:  path.Join(pixEndpoint, "loc"),
+		Scopes:    []auth.Scope{auth.ScopePayloadLocationWrite},
+		Body: &CriarLocRequest{
+			TipoCob: tipoCob,
+		},
+		Result: result,
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ConsultarLoc consulta uma location do payload pelo seu identificador
+func (c *Service) ConsultarLoc(ctx context.Context, id int64) (*LocResponse, error) {
+	result := &LocResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.ConsultarLoc",
+		Method:     resty.MethodGet,
+		Endpoint:   path.Join(pixEndpoint, "loc", "{id}"),
+		PathParams: map[string]string{"id": strconv.FormatInt(id, 10)},
+		Scopes:     []auth.Scope{auth.ScopePayloadLocationRead},
+		Result:     result,
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ConsultarLocs consulta as locations cadastradas de acordo com os filtros informados
+func (c *Service) ConsultarLocs(ctx context.Context, request *ConsultarLocsRequest) (*ConsultarLocsResponse, error) {
+	result := &ConsultarLocsResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "pix.ConsultarLocs",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(pixEndpoint, "loc"),
+		Query:     interutils.StructToMap(request),
+		Scopes:    []auth.Scope{auth.ScopePayloadLocationRead},
+		Result:    result,
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DesvincularLoc desvincula o txid de uma location do payload, permitindo reutilizá-la em outra cobrança
+func (c *Service) DesvincularLoc(ctx context.Context, id int64) (*LocResponse, error) {
+	result := &LocResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.DesvincularLoc",
+		Method:     resty.MethodDelete,
+		Endpoint:   path.Join(pixEndpoint, "loc", "{id}", "txid"),
+		PathParams: map[string]string{"id": strconv.FormatInt(id, 10)},
+		Scopes:     []auth.Scope{auth.ScopePayloadLocationWrite},
+		Idempotent: true,
+		Result:     result,
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
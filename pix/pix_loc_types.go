@@ -0,0 +1,41 @@
+package pix
+
+// CriarLocRequest representa a requisição para criar uma location do payload
+type CriarLocRequest struct {
+	TipoCob TipoCobranca `json:"tipoCob"` // Tipo da cobrança - Enum: "cob" "cobv"
+}
+
+// LocResponse representa uma location do payload
+type LocResponse struct {
+	ID       int64        `json:"id"`                // Identificador da location
+	TxId     string       `json:"txid,omitempty"`    // Identificador da transação vinculada à location, quando houver
+	Location string       `json:"location"`          // Localização do payload
+	TipoCob  TipoCobranca `json:"tipoCob"`           // Tipo da cobrança - Enum: "cob" "cobv"
+	Criacao  string       `json:"criacao,omitempty"` // Data e hora de criação da location
+}
+
+// ToLoc retorna o identificador da location para ser informado no campo Loc de uma cobrança
+func (l *LocResponse) ToLoc() *Loc {
+	return &Loc{
+		ID:       l.ID,
+		TipoCob:  l.TipoCob,
+		Location: l.Location,
+		Criacao:  l.Criacao,
+	}
+}
+
+// ConsultarLocsRequest representa a requisição para consultar locations cadastradas
+type ConsultarLocsRequest struct {
+	Inicio                  string       `json:"inicio"`                             // Data de início da consulta
+	Fim                     string       `json:"fim"`                                // Data de fim da consulta
+	TxIdPresente            *bool        `json:"txIdPresente,omitempty"`             // Filtra locations com (true) ou sem (false) txid vinculado
+	TipoCob                 TipoCobranca `json:"tipoCob,omitempty"`                  // Tipo da cobrança - Enum: "cob" "cobv"
+	PaginacaoPaginaAtual    int32        `json:"paginacao.paginaAtual,omitempty"`    // Página atual
+	PaginacaoItensPorPagina int32        `json:"paginacao.itensPorPagina,omitempty"` // Itens por página
+}
+
+// ConsultarLocsResponse representa a resposta da consulta de locations
+type ConsultarLocsResponse struct {
+	Parametros *ParametrosConsulta `json:"parametros"` // Parâmetros da consulta
+	Loc        []*LocResponse      `json:"loc"`        // Locations encontradas
+}
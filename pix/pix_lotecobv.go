@@ -0,0 +1,84 @@
+package pix
+
+import (
+	"context"
+	"path"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/raniellyferreira/interbank-go/auth"
+	"github.com/raniellyferreira/interbank-go/backend"
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+// CriarLoteCobrancaComVencimento cria ou substitui um lote de cobranças com vencimento.
+// O processamento é assíncrono, use ConsultarLoteCobrancaComVencimento para acompanhar a situação de cada cobrança.
+func (c *Service) CriarLoteCobrancaComVencimento(ctx context.Context, id int64, request *LoteCobrancaComVencimentoRequest) error {
+	request, err := loteCobrancaComVencimentoComChave(request)
+	if err != nil {
+		return err
+	}
+
+	return c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.CriarLoteCobrancaComVencimento",
+		Method:     resty.MethodPut,
+		Endpoint:   path.Join(pixEndpoint, "lotecobv", "{id}"),
+		PathParams: map[string]string{"id": strconv.FormatInt(id, 10)},
+		Scopes:     []auth.Scope{auth.ScopeLoteCobVWrite},
+		Idempotent: true,
+		Body:       request,
+	})
+}
+
+// EditarLoteCobrancaComVencimento altera cobranças específicas de um lote de cobranças com vencimento
+func (c *Service) EditarLoteCobrancaComVencimento(ctx context.Context, id int64, request *EditarLoteCobrancaComVencimentoRequest) error {
+	request, err := editarLoteCobrancaComVencimentoComChave(request)
+	if err != nil {
+		return err
+	}
+
+	return c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.EditarLoteCobrancaComVencimento",
+		Method:     resty.MethodPatch,
+		Endpoint:   path.Join(pixEndpoint, "lotecobv", "{id}"),
+		PathParams: map[string]string{"id": strconv.FormatInt(id, 10)},
+		Scopes:     []auth.Scope{auth.ScopeLoteCobVWrite},
+		Body:       request,
+	})
+}
+
+// ConsultarLoteCobrancaComVencimento consulta um lote de cobranças com vencimento e a situação de cada cobrança
+func (c *Service) ConsultarLoteCobrancaComVencimento(ctx context.Context, id int64) (*LoteCobrancaComVencimentoResponse, error) {
+	result := &LoteCobrancaComVencimentoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation:  "pix.ConsultarLoteCobrancaComVencimento",
+		Method:     resty.MethodGet,
+		Endpoint:   path.Join(pixEndpoint, "lotecobv", "{id}"),
+		PathParams: map[string]string{"id": strconv.FormatInt(id, 10)},
+		Scopes:     []auth.Scope{auth.ScopeLoteCobVRead},
+		Result:     result,
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ConsultarLotesCobrancaComVencimento consulta os lotes de cobranças com vencimento em um período
+func (c *Service) ConsultarLotesCobrancaComVencimento(ctx context.Context, request *ConsultarLotesCobrancaComVencimentoRequest) (*ConsultarLotesCobrancaComVencimentoResponse, error) {
+	result := &ConsultarLotesCobrancaComVencimentoResponse{}
+
+	if err := c.backend.Do(ctx, &backend.Call{
+		Operation: "pix.ConsultarLotesCobrancaComVencimento",
+		Method:    resty.MethodGet,
+		Endpoint:  path.Join(pixEndpoint, "lotecobv"),
+		Query:     interutils.StructToMap(request),
+		Scopes:    []auth.Scope{auth.ScopeLoteCobVRead},
+		Result:    result,
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
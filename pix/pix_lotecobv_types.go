@@ -0,0 +1,95 @@
+package pix
+
+import "github.com/raniellyferreira/interbank-go/erros"
+
+type LoteCobVStatus string
+
+const (
+	LoteCobVStatusEmProcessamento LoteCobVStatus = "EM_PROCESSAMENTO" // A cobrança ainda está sendo processada
+	LoteCobVStatusCriada          LoteCobVStatus = "CRIADA"           // A cobrança foi criada com sucesso
+	LoteCobVStatusNegada          LoteCobVStatus = "NEGADA"           // A cobrança foi negada, ver o campo Problema
+)
+
+// LoteCobrancaComVencimentoItem representa uma cobrança com vencimento dentro de um lote
+type LoteCobrancaComVencimentoItem struct {
+	TxId string `json:"txid"` // Identificador da transação
+
+	*CobrancaComVencimentoRequest
+}
+
+// LoteCobrancaComVencimentoRequest representa a requisição para criar ou substituir um lote de cobranças com vencimento
+type LoteCobrancaComVencimentoRequest struct {
+	Descricao string                           `json:"descricao,omitempty"` // Descrição do lote
+	CobsV     []*LoteCobrancaComVencimentoItem `json:"cobsv"`               // Cobranças com vencimento do lote
+}
+
+// EditarLoteCobrancaComVencimentoItem representa as alterações de uma cobrança com vencimento dentro de um lote.
+// Apenas os campos preenchidos são enviados; os demais permanecem inalterados.
+type EditarLoteCobrancaComVencimentoItem struct {
+	TxId string `json:"txid"` // Identificador da transação
+
+	Calendario         *CalendarioComVencimento    `json:"calendario,omitempty"`         // Calendário com vencimento
+	Devedor            *Identificador              `json:"devedor,omitempty"`            // Devedor
+	Loc                *Loc                        `json:"loc,omitempty"`                // Identificador da localização do payload
+	Valor              *ValorCobrancaComVencimento `json:"valor,omitempty"`              // Valor da cobrança
+	Chave              string                      `json:"chave,omitempty"`              // Chave Pix do recebedor
+	InfoAdicionais     []*InfoAdicional            `json:"infoAdicionais,omitempty"`     // Informações adicionais
+	SolicitacaoPagador string                      `json:"solicitacaoPagador,omitempty"` // Solicitação do pagador
+}
+
+// EditarLoteCobrancaComVencimentoRequest representa a requisição para alterar cobranças específicas de um lote
+type EditarLoteCobrancaComVencimentoRequest struct {
+	Descricao string                                 `json:"descricao,omitempty"` // Descrição do lote
+	CobsV     []*EditarLoteCobrancaComVencimentoItem `json:"cobsv"`               // Alterações das cobranças do lote
+}
+
+// LoteCobrancaComVencimentoItemResponse representa a situação de uma cobrança dentro de um lote
+type LoteCobrancaComVencimentoItemResponse struct {
+	TxId     string          `json:"txid"`               // Identificador da transação
+	Criacao  string          `json:"criacao,omitempty"`  // Data e hora de criação da cobrança
+	Status   LoteCobVStatus  `json:"status"`             // Status da cobrança no lote
+	Problema *erros.Response `json:"problema,omitempty"` // Problema encontrado quando a cobrança é negada
+}
+
+// LoteCobrancaComVencimentoResponse representa um lote de cobranças com vencimento
+type LoteCobrancaComVencimentoResponse struct {
+	ID        int64                                    `json:"id,omitempty"`        // Identificador do lote
+	Descricao string                                   `json:"descricao,omitempty"` // Descrição do lote
+	Criacao   string                                   `json:"criacao,omitempty"`   // Data e hora de criação do lote
+	CobsV     []*LoteCobrancaComVencimentoItemResponse `json:"cobsv"`               // Situação das cobranças do lote
+}
+
+// EmProcessamento retorna true enquanto houver cobranças do lote em processamento
+func (l *LoteCobrancaComVencimentoResponse) EmProcessamento() bool {
+	for _, item := range l.CobsV {
+		if item.Status == LoteCobVStatusEmProcessamento {
+			return true
+		}
+	}
+	return false
+}
+
+// Negadas retorna as cobranças do lote que foram negadas
+func (l *LoteCobrancaComVencimentoResponse) Negadas() []*LoteCobrancaComVencimentoItemResponse {
+	var negadas []*LoteCobrancaComVencimentoItemResponse
+	for _, item := range l.CobsV {
+		if item.Status == LoteCobVStatusNegada {
+			negadas = append(negadas, item)
+		}
+	}
+	return negadas
+}
+
+// ConsultarLotesCobrancaComVencimentoRequest representa a requisição para consultar lotes de cobranças com vencimento
+type ConsultarLotesCobrancaComVencimentoRequest struct {
+	Inicio                  string `json:"inicio"`                             // Data de início da consulta
+	Fim                     string `json:"fim"`                                // Data de fim da consulta
+	PaginacaoPaginaAtual    int32  `json:"paginacao.paginaAtual,omitempty"`    // Página atual
+	PaginacaoItensPorPagina int32  `json:"paginacao.itensPorPagina,omitempty"` // Itens por página
+}
+
+// ConsultarLotesCobrancaComVencimentoResponse representa a resposta da consulta de lotes de cobranças com vencimento
+type ConsultarLotesCobrancaComVencimentoResponse struct {
+	Parametros *ParametrosConsulta                  `json:"parametros"` // Parâmetros da consulta
+	Lotes      []*LoteCobrancaComVencimentoResponse `json:"lotes"`      // Lotes encontrados
+}
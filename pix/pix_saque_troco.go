@@ -0,0 +1,222 @@
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/raniellyferreira/interbank-go/pix/chave"
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+// ErrRetiradaInvalida é retornado quando uma cobrança de Pix Saque ou Pix Troco não respeita as regras do arranjo
+var ErrRetiradaInvalida = errors.New("pix saque/troco inválido")
+
+// reISPB valida o ISPB do facilitador de serviço de saque (8 dígitos)
+var reISPB = regexp.MustCompile(`^[0-9]{8}$`)
+
+// ValidarRetirada verifica as regras de Pix Saque e Pix Troco do valor de uma cobrança:
+//
+//   - saque e troco (e retirada) não podem coexistir;
+//   - o componente deve ter valor positivo, modalidade de agente e o ISPB do prestador do serviço de saque;
+//   - Pix Saque: o valor original deve ser 0.00 e não pode ser alterado pelo pagador;
+//   - Pix Troco: o valor original (compra) deve ser positivo, não pode ser alterado pelo pagador,
+//     e a modalidade de agente AGPSS não é permitida.
+//
+// Retorna nil se o valor não tiver componentes de retirada.
+func ValidarRetirada(valor *ValorCobranca) error {
+	if valor == nil {
+		return nil
+	}
+
+	componentes := 0
+	for _, componente := range []*ComponenteValorCobranca{valor.Retirada, valor.Saque, valor.Troco} {
+		if componente != nil {
+			componentes++
+		}
+	}
+
+	switch {
+	case componentes == 0:
+		return nil
+	case componentes > 1:
+		return fmt.Errorf("%w: saque e troco não podem coexistir na mesma cobrança", ErrRetiradaInvalida)
+	}
+
+	original, err := interutils.ParseCentavos(valor.Original)
+	if err != nil {
+		return fmt.Errorf("%w: valor original %q: %w", ErrRetiradaInvalida, valor.Original, err)
+	}
+
+	if (valor.Saque != nil || valor.Troco != nil) && valor.ModalidadeAlteracao != ModalidadeAlteracaoNaoPermitido {
+		return fmt.Errorf("%w: o valor original não pode ser alterado pelo pagador", ErrRetiradaInvalida)
+	}
+
+	switch {
+	case valor.Saque != nil:
+		if original != 0 {
+			return fmt.Errorf("%w: o valor original de um Pix Saque deve ser 0.00", ErrRetiradaInvalida)
+		}
+		return validarComponenteRetirada("saque", valor.Saque)
+
+	case valor.Troco != nil:
+		if original <= 0 {
+			return fmt.Errorf("%w: o valor original (compra) de um Pix Troco deve ser positivo", ErrRetiradaInvalida)
+		}
+		if valor.Troco.ModalidadeAgente == ModalidadeAgenteFacilitadorDeServicoDeSaque {
+			return fmt.Errorf("%w: troco: modalidade de agente %s não permitida", ErrRetiradaInvalida, valor.Troco.ModalidadeAgente)
+		}
+		return validarComponenteRetirada("troco", valor.Troco)
+	}
+
+	return validarComponenteRetirada("retirada", valor.Retirada)
+}
+
+// validarComponenteRetirada checks the fields required in every withdrawal component
+func validarComponenteRetirada(nome string, componente *ComponenteValorCobranca) error {
+	centavos, err := interutils.ParseCentavos(componente.Valor)
+	if err != nil {
+		return fmt.Errorf("%w: %s: valor %q: %w", ErrRetiradaInvalida, nome, componente.Valor, err)
+	}
+	if centavos <= 0 {
+		return fmt.Errorf("%w: %s: o valor deve ser positivo", ErrRetiradaInvalida, nome)
+	}
+
+	switch componente.ModalidadeAgente {
+	case ModalidadeAgenteEstabelecimentoComercial,
+		ModalidadeAgenteOutraEspecieDePessoaJuridicaOuCorrespondenteNoPais,
+		ModalidadeAgenteFacilitadorDeServicoDeSaque:
+	case "":
+		return fmt.Errorf("%w: %s: modalidade de agente não informada", ErrRetiradaInvalida, nome)
+	default:
+		return fmt.Errorf("%w: %s: modalidade de agente desconhecida %q", ErrRetiradaInvalida, nome, componente.ModalidadeAgente)
+	}
+
+	if !reISPB.MatchString(componente.PrestadorDoServicoDeSaque) {
+		return fmt.Errorf("%w: %s: ISPB do prestador do serviço de saque inválido %q", ErrRetiradaInvalida, nome, componente.PrestadorDoServicoDeSaque)
+	}
+
+	switch componente.ModalidadeAlteracao {
+	case ModalidadeAlteracaoNaoPermitido, ModalidadeAlteracaoPermitido:
+	default:
+		return fmt.Errorf("%w: %s: modalidade de alteração desconhecida %d", ErrRetiradaInvalida, nome, componente.ModalidadeAlteracao)
+	}
+
+	return nil
+}
+
+// CobrancaRetiradaBuilder constrói cobranças imediatas de Pix Saque e Pix Troco.
+// Use NewPixSaque ou NewPixTroco e finalize com Build, que valida as regras do arranjo.
+type CobrancaRetiradaBuilder struct {
+	troco bool
+
+	chave              string
+	original           string
+	componente         ComponenteValorCobranca
+	expiracao          int32
+	devedor            *Identificador
+	solicitacaoPagador string
+	infoAdicionais     []*InfoAdicional
+}
+
+// NewPixSaque creates a builder for a Pix Saque charge of the given withdrawal amount
+func NewPixSaque(chave, valorSaque string) *CobrancaRetiradaBuilder {
+	return &CobrancaRetiradaBuilder{
+		chave:      chave,
+		original:   "0.00",
+		componente: ComponenteValorCobranca{Valor: valorSaque},
+	}
+}
+
+// NewPixTroco creates a builder for a Pix Troco charge of the given purchase and change amounts
+func NewPixTroco(chave, valorCompra, valorTroco string) *CobrancaRetiradaBuilder {
+	return &CobrancaRetiradaBuilder{
+		troco:      true,
+		chave:      chave,
+		original:   valorCompra,
+		componente: ComponenteValorCobranca{Valor: valorTroco},
+	}
+}
+
+// SetAgente sets the agent modality and the ISPB of the withdrawal service provider
+func (b *CobrancaRetiradaBuilder) SetAgente(modalidade ModalidadeAgente, prestadorDoServicoDeSaque string) *CobrancaRetiradaBuilder {
+	b.componente.ModalidadeAgente = modalidade
+	b.componente.PrestadorDoServicoDeSaque = prestadorDoServicoDeSaque
+	return b
+}
+
+// SetModalidadeAlteracao sets whether the payer can change the withdrawal (or change) amount
+func (b *CobrancaRetiradaBuilder) SetModalidadeAlteracao(modalidade ModalidadeAlteracao) *CobrancaRetiradaBuilder {
+	b.componente.ModalidadeAlteracao = modalidade
+	return b
+}
+
+// SetExpiracao sets the charge expiration in seconds
+func (b *CobrancaRetiradaBuilder) SetExpiracao(expiracao int32) *CobrancaRetiradaBuilder {
+	b.expiracao = expiracao
+	return b
+}
+
+// SetDevedor sets the debtor of the charge
+func (b *CobrancaRetiradaBuilder) SetDevedor(devedor *Identificador) *CobrancaRetiradaBuilder {
+	b.devedor = devedor
+	return b
+}
+
+// SetSolicitacaoPagador sets the text presented to the payer
+func (b *CobrancaRetiradaBuilder) SetSolicitacaoPagador(solicitacao string) *CobrancaRetiradaBuilder {
+	b.solicitacaoPagador = solicitacao
+	return b
+}
+
+// AddInfoAdicional adds an additional information presented to the payer
+func (b *CobrancaRetiradaBuilder) AddInfoAdicional(nome, valor string) *CobrancaRetiradaBuilder {
+	b.infoAdicionais = append(b.infoAdicionais, &InfoAdicional{Nome: nome, Valor: valor})
+	return b
+}
+
+// Build valida as regras de Pix Saque/Troco e retorna a cobrança imediata pronta para ser criada
+func (b *CobrancaRetiradaBuilder) Build() (*CobrancaImediataRequest, error) {
+	chavePix, err := chave.Normalizar(b.chave)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b.solicitacaoPagador) > 140 {
+		return nil, fmt.Errorf("%w: solicitacaoPagador excede 140 caracteres", ErrRetiradaInvalida)
+	}
+
+	componente := b.componente
+	valor := &ValorCobranca{
+		Original:            b.original,
+		ModalidadeAlteracao: ModalidadeAlteracaoNaoPermitido,
+	}
+	if b.troco {
+		valor.Troco = &componente
+	} else {
+		valor.Saque = &componente
+	}
+
+	if err := ValidarRetirada(valor); err != nil {
+		return nil, err
+	}
+
+	// Normaliza os valores para o formato do Bacen (duas casas decimais)
+	original, _ := interutils.ParseCentavos(valor.Original)
+	retirada, _ := interutils.ParseCentavos(componente.Valor)
+	valor.Original = interutils.FormatCentavos(original)
+	componente.Valor = interutils.FormatCentavos(retirada)
+
+	request := &CobrancaImediataRequest{
+		Devedor:            b.devedor,
+		Valor:              valor,
+		Chave:              chavePix,
+		InfoAdicionais:     b.infoAdicionais,
+		SolicitacaoPagador: b.solicitacaoPagador,
+	}
+	if b.expiracao > 0 {
+		request.Calendario = &CalendarioSemVencimento{Expiracao: b.expiracao}
+	}
+
+	return request, nil
+}
@@ -3,7 +3,7 @@ package pix
 import (
 	"time"
 
-	"github.com/google/uuid"
+	interutils "github.com/raniellyferreira/interbank-go/utils"
 )
 
 // RecebidosRequest representa a requisição de pix recebidos
@@ -25,6 +25,19 @@ type RecebidosResponse struct {
 	Pix        []*Pix              `json:"pix"`
 }
 
+// PagarCobrancaRequest representa a requisição de pagamento de uma cobrança (SandBox apenas)
+type PagarCobrancaRequest struct {
+	Valor string `json:"valor"` // Valor a ser pago
+}
+
+// PagarCobrancaResponse representa a resposta do pagamento de uma cobrança (SandBox apenas)
+type PagarCobrancaResponse struct {
+	EndToEndID string `json:"endToEndId,omitempty"` // Identificador único do pix gerado
+	TxId       string `json:"txid,omitempty"`       // Identificador da transação
+	Valor      string `json:"valor,omitempty"`      // Valor pago
+	Horario    string `json:"horario,omitempty"`    // Horário do pagamento
+}
+
 // Pix representa a resposta de um pix
 type Pix struct {
 	// EndToEndID é o identificador único do pix
@@ -135,6 +148,9 @@ type DevolucaoResponse struct {
 	// Status é o status da devolução
 	Status DevolucaoStatus `json:"status"`
 
+	// Natureza é a natureza da devolução (ORIGINAL ou RETIRADA)
+	Natureza NaturezaDevolucaoPix `json:"natureza,omitempty"`
+
 	// Descrição é a descrição da devolução
 	Descricao string `json:"descricao,omitempty"`
 }
@@ -182,9 +198,11 @@ type SolicitarDevolucaoPixRequest struct {
 	Descricao string `json:"descricao,omitempty"`
 }
 
+// GetLocalUniqId returns the devolução id, generating a random one if empty.
+// Use DerivarIDDevolucao to get an id that is safe to retry.
 func (r *SolicitarDevolucaoPixRequest) GetLocalUniqId() string {
 	if r.LocalUniqId == "" {
-		r.LocalUniqId = uuid.NewString()
+		r.LocalUniqId = interutils.UUIDString()
 	}
 	return r.LocalUniqId
 }
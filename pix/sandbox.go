@@ -0,0 +1,145 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+// ErrAmbienteProducao é retornado pelos simuladores do sandbox quando o cliente aponta para produção
+var ErrAmbienteProducao = errors.New("sandbox simulator cannot run against the production environment")
+
+// SimulacaoPagamento é o resultado de SimularPagamento
+type SimulacaoPagamento struct {
+	TxId      string                 // Identificador da transação paga
+	Pagamento *PagarCobrancaResponse // Resposta de PagarCobranca
+	Status    CobrancaStatus         // Status final da cobrança (CONCLUIDA)
+	Pix       *Pix                   // Pix recebido, conforme ConsultarRecebidos
+}
+
+// SimularPagamento paga uma cobrança no sandbox e aguarda até que ela esteja CONCLUIDA e o Pix
+// correspondente apareça em ConsultarRecebidos. O tempo máximo de espera é definido pelo deadline do contexto.
+//
+// Quando valor é vazio, é usado o valor original da cob ou o valor calculado da cobv para a data atual.
+// Retorna ErrAmbienteProducao se o cliente estiver configurado para o ambiente de produção.
+func (c *Service) SimularPagamento(ctx context.Context, tipoCob TipoCobranca, txID, valor string, config *AguardarConfig) (*SimulacaoPagamento, error) {
+	if c.backend.IsProduction() {
+		return nil, ErrAmbienteProducao
+	}
+
+	if valor == "" {
+		var err error
+		valor, err = c.valorCobranca(ctx, tipoCob, txID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inicio := time.Now().Add(-time.Hour)
+	pagamento, err := c.PagarCobranca(ctx, tipoCob, txID, valor)
+	if err != nil {
+		return nil, err
+	}
+
+	simulacao := &SimulacaoPagamento{
+		TxId:      txID,
+		Pagamento: pagamento,
+	}
+
+	intervaloInicial, intervaloMaximo := config.intervalos()
+	naoEncontradoAte := config.naoEncontradoAte()
+	for attempt := 0; ; attempt++ {
+		if err := interutils.Sleep(ctx, interutils.Backoff(attempt, intervaloInicial, intervaloMaximo)); err != nil {
+			return simulacao, err
+		}
+
+		if simulacao.Status != CobrancaStatusConcluida {
+			status, err := c.statusCobranca(ctx, tipoCob, txID)
+			if err != nil {
+				if ctx.Err() == nil && consultaRecuperavel(err, naoEncontradoAte) {
+					continue
+				}
+				return simulacao, err
+			}
+
+			simulacao.Status = status
+			if status != CobrancaStatusAtiva && status != CobrancaStatusConcluida {
+				return simulacao, fmt.Errorf("cobrança %s %s: status %s", tipoCob, txID, status)
+			}
+			if status != CobrancaStatusConcluida {
+				continue
+			}
+		}
+
+		recebidos, err := c.ConsultarRecebidos(ctx, &RecebidosRequest{
+			Inicio: interutils.FormatTime(inicio),
+			Fim:    interutils.FormatTime(time.Now().Add(time.Hour)),
+			TxID:   txID,
+		})
+		if err != nil {
+			if ctx.Err() == nil && consultaRecuperavel(err, naoEncontradoAte) {
+				continue
+			}
+			return simulacao, err
+		}
+
+		for _, pix := range recebidos.Pix {
+			if pix != nil && pix.Txid == txID {
+				simulacao.Pix = pix
+				return simulacao, nil
+			}
+		}
+	}
+}
+
+// valorCobranca returns the amount to be paid for the charge now
+func (c *Service) valorCobranca(ctx context.Context, tipoCob TipoCobranca, txID string) (string, error) {
+	switch tipoCob {
+	case CobrancaSemValidade:
+		cob, err := c.ConsultarCobrancaImediata(ctx, txID)
+		if err != nil {
+			return "", err
+		}
+		if cob.Valor == nil {
+			return "", fmt.Errorf("cob %s has no valor", txID)
+		}
+		return cob.Valor.Original, nil
+
+	case CobrancaComValidade:
+		cobv, err := c.ConsultarCobrancaComVencimento(ctx, txID)
+		if err != nil {
+			return "", err
+		}
+		calculo, err := cobv.Calcular(time.Now())
+		if err != nil {
+			return "", err
+		}
+		return calculo.Valor(), nil
+	}
+
+	return "", fmt.Errorf("unknown tipo de cobrança %q", tipoCob)
+}
+
+// statusCobranca returns the current status of the charge
+func (c *Service) statusCobranca(ctx context.Context, tipoCob TipoCobranca, txID string) (CobrancaStatus, error) {
+	switch tipoCob {
+	case CobrancaSemValidade:
+		cob, err := c.ConsultarCobrancaImediata(ctx, txID)
+		if err != nil {
+			return "", err
+		}
+		return cob.Status, nil
+
+	case CobrancaComValidade:
+		cobv, err := c.ConsultarCobrancaComVencimento(ctx, txID)
+		if err != nil {
+			return "", err
+		}
+		return cobv.Status, nil
+	}
+
+	return "", fmt.Errorf("unknown tipo de cobrança %q", tipoCob)
+}
@@ -0,0 +1,190 @@
+package pix
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/raniellyferreira/interbank-go/erros"
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+var (
+	// ErrTxIDInvalido é retornado quando o txid não segue o formato do Bacen (26 a 35 caracteres alfanuméricos)
+	ErrTxIDInvalido = errors.New("txid inválido")
+
+	// ErrIDDevolucaoInvalido é retornado quando o id da devolução não segue o formato do Bacen (1 a 35 caracteres alfanuméricos)
+	ErrIDDevolucaoInvalido = errors.New("id de devolução inválido")
+
+	// ErrTxIDEmUso é retornado quando o txid já foi usado por uma cobrança diferente da solicitada
+	ErrTxIDEmUso = errors.New("txid já utilizado por outra cobrança")
+
+	// ErrIDDevolucaoEmUso é retornado quando o id já foi usado por uma devolução diferente da solicitada
+	ErrIDDevolucaoEmUso = errors.New("id de devolução já utilizado por outra devolução")
+)
+
+var (
+	reTxID        = regexp.MustCompile(`^[a-zA-Z0-9]{26,35}$`)
+	reIDDevolucao = regexp.MustCompile(`^[a-zA-Z0-9]{1,35}$`)
+	reJaExiste    = regexp.MustCompile(`(?i)j[áa] (foi )?(existe|utilizad|cadastrad)|already exists|duplicad`)
+
+	// namespaceInterbank é a raiz dos namespaces usados na derivação de identificadores
+	namespaceInterbank = uuid.NewSHA1(uuid.NameSpaceURL, []byte("github.com/raniellyferreira/interbank-go"))
+)
+
+// ValidarTxID retorna um erro se o txid não tiver de 26 a 35 caracteres alfanuméricos
+func ValidarTxID(txID string) error {
+	if !reTxID.MatchString(txID) {
+		return fmt.Errorf("%w: %q", ErrTxIDInvalido, txID)
+	}
+	return nil
+}
+
+// ValidarIDDevolucao retorna um erro se o id da devolução não tiver de 1 a 35 caracteres alfanuméricos
+func ValidarIDDevolucao(id string) error {
+	if !reIDDevolucao.MatchString(id) {
+		return fmt.Errorf("%w: %q", ErrIDDevolucaoInvalido, id)
+	}
+	return nil
+}
+
+// DerivarTxID gera um txid determinístico (32 caracteres hexadecimais) a partir de um namespace e das
+// chaves de negócio, por exemplo DerivarTxID("pedido", pedidoID). As mesmas entradas sempre geram o
+// mesmo txid, o que torna seguro repetir CriarCobrancaImediataComTxID e CriarCobrancaComVencimentoETxID
+// após um timeout sem criar cobranças duplicadas: quando a cobrança já existe, ela é consultada e retornada.
+func DerivarTxID(namespace string, chaves ...string) string {
+	return derivar("txid", namespace, chaves)
+}
+
+// DerivarIDDevolucao gera um id de devolução determinístico (32 caracteres hexadecimais) a partir de um
+// namespace e das chaves de negócio, para ser usado em SolicitarDevolucaoPixRequest.LocalUniqId
+func DerivarIDDevolucao(namespace string, chaves ...string) string {
+	return derivar("devolucao", namespace, chaves)
+}
+
+// derivar computes a UUIDv5 (SHA-1) of the keys inside the namespace, without dashes
+func derivar(tipo, namespace string, chaves []string) string {
+	ns := uuid.NewSHA1(namespaceInterbank, []byte(tipo+":"+namespace))
+
+	// Prefixa cada chave com o seu tamanho para que ("ab", "c") e ("a", "bc") gerem ids diferentes
+	var nome []byte
+	for _, chave := range chaves {
+		nome = binary.AppendUvarint(nome, uint64(len(chave)))
+		nome = append(nome, chave...)
+	}
+
+	return strings.ReplaceAll(uuid.NewSHA1(ns, nome).String(), "-", "")
+}
+
+// jaExiste returns true if the API rejected a creation via PUT because the identifier is already in use
+func jaExiste(err error) bool {
+	if erros.IsConflict(err) {
+		return true
+	}
+
+	var resp *erros.Response
+	if !errors.As(err, &resp) || !erros.IsValidation(err) {
+		return false
+	}
+	if reJaExiste.MatchString(resp.GetTitle()) || reJaExiste.MatchString(resp.GetMessage()) {
+		return true
+	}
+	for _, violation := range resp.Violations {
+		if reJaExiste.MatchString(violation.Reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// cobrancaImediataExistente returns the charge created by a previous attempt with the same txid,
+// or ErrTxIDEmUso if the txid belongs to a different charge
+func (c *Service) cobrancaImediataExistente(ctx context.Context, txID string, request *CobrancaImediataRequest, err error) (*CobrancaImediataResponse, error) {
+	cob, consultaErr := c.ConsultarCobrancaImediata(ctx, txID)
+	if consultaErr != nil {
+		return nil, err
+	}
+
+	var original string
+	if cob.Valor != nil {
+		original = cob.Valor.Original
+	}
+	var solicitado string
+	if request.Valor != nil {
+		solicitado = request.Valor.Original
+	}
+
+	if !mesmaCobranca(request.Chave, solicitado, request.Devedor, cob.Chave, original, cob.Devedor) {
+		return nil, fmt.Errorf("%w: %s: %w", ErrTxIDEmUso, txID, err)
+	}
+	return cob, nil
+}
+
+// cobrancaComVencimentoExistente returns the charge created by a previous attempt with the same txid,
+// or ErrTxIDEmUso if the txid belongs to a different charge
+func (c *Service) cobrancaComVencimentoExistente(ctx context.Context, txID string, request *CobrancaComVencimentoRequest, err error) (*CobrancaComVencimentoResponse, error) {
+	cob, consultaErr := c.ConsultarCobrancaComVencimento(ctx, txID)
+	if consultaErr != nil {
+		return nil, err
+	}
+
+	var original string
+	if cob.Valor != nil {
+		original = cob.Valor.Original
+	}
+	var solicitado string
+	if request.Valor != nil {
+		solicitado = request.Valor.Original
+	}
+
+	if !mesmaCobranca(request.Chave, solicitado, request.Devedor, cob.Chave, original, cob.Devedor) {
+		return nil, fmt.Errorf("%w: %s: %w", ErrTxIDEmUso, txID, err)
+	}
+	return cob, nil
+}
+
+// devolucaoExistente returns the refund requested by a previous attempt with the same id,
+// or ErrIDDevolucaoEmUso if the id belongs to a different refund
+func (c *Service) devolucaoExistente(ctx context.Context, request *SolicitarDevolucaoPixRequest, err error) (*DevolucaoResponse, error) {
+	devolucao, consultaErr := c.ConsultarDevolucao(ctx, request.EndToEndID, request.LocalUniqId)
+	if consultaErr != nil {
+		return nil, err
+	}
+
+	if math.Abs(devolucao.Valor-request.Valor) >= 0.005 {
+		return nil, fmt.Errorf("%w: %s: %w", ErrIDDevolucaoEmUso, request.LocalUniqId, err)
+	}
+	return devolucao, nil
+}
+
+// mesmaCobranca compares the fields that identify a charge: key, original amount and debtor document.
+// Fields not informed in the request are not compared.
+func mesmaCobranca(chave, valor string, devedor *Identificador, chaveExistente, valorExistente string, devedorExistente *Identificador) bool {
+	if chave != "" && chave != chaveExistente {
+		return false
+	}
+
+	if valor != "" {
+		solicitado, err := interutils.ParseCentavos(valor)
+		if err != nil {
+			return false
+		}
+		existente, err := interutils.ParseCentavos(valorExistente)
+		if err != nil || solicitado != existente {
+			return false
+		}
+	}
+
+	if devedor != nil {
+		if devedorExistente == nil || devedor.Cpf != devedorExistente.Cpf || devedor.Cnpj != devedorExistente.Cnpj {
+			return false
+		}
+	}
+
+	return true
+}
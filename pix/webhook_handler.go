@@ -0,0 +1,270 @@
+package pix
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	interutils "github.com/raniellyferreira/interbank-go/utils"
+)
+
+const (
+	// DefaultWebhookMaxBodySize é o tamanho máximo padrão do corpo de uma notificação (1 MiB)
+	DefaultWebhookMaxBodySize int64 = 1 << 20
+
+	// DefaultSeenStoreTTL é o tempo padrão que um evento permanece no MemorySeenStore
+	DefaultSeenStoreTTL = 72 * time.Hour
+)
+
+// WebhookPixFunc é chamada para cada Pix recebido no webhook.
+// Retornar um erro faz com que o handler responda com status 500 para que o Inter reenvie a notificação.
+type WebhookPixFunc func(ctx context.Context, pix *Pix) error
+
+// SeenStore guarda os eventos de webhook já processados para descartar notificações duplicadas
+type SeenStore interface {
+	// Claim marca o evento como processado de forma atômica (check-and-set) e retorna true
+	// se a chamada obteve o evento, ou false se ele já foi obtido por outra entrega
+	Claim(ctx context.Context, key string) (bool, error)
+
+	// Release desfaz o Claim de um evento cujo processamento falhou, para que a reentrega seja processada
+	Release(ctx context.Context, key string) error
+}
+
+// WebhookHandler é um http.Handler que recebe as notificações de Pix do Inter
+type WebhookHandler struct {
+	callback    WebhookPixFunc
+	seen        SeenStore
+	clientCAs   *x509.CertPool
+	maxBodySize int64
+}
+
+// NewWebhookHandler creates a new webhook handler that calls the callback for each received Pix
+func NewWebhookHandler(callback WebhookPixFunc) *WebhookHandler {
+	return &WebhookHandler{
+		callback:    callback,
+		seen:        NewMemorySeenStore(DefaultSeenStoreTTL),
+		maxBodySize: DefaultWebhookMaxBodySize,
+	}
+}
+
+// SetSeenStore sets the store used to drop duplicated events (nil disables de-duplication)
+func (h *WebhookHandler) SetSeenStore(store SeenStore) *WebhookHandler {
+	h.seen = store
+	return h
+}
+
+// SetClientCAs sets the pool used to verify the client certificate chain sent by Inter
+func (h *WebhookHandler) SetClientCAs(pool *x509.CertPool) *WebhookHandler {
+	h.clientCAs = pool
+	return h
+}
+
+// SetMaxBodySize sets the maximum accepted body size in bytes
+func (h *WebhookHandler) SetMaxBodySize(size int64) *WebhookHandler {
+	h.maxBodySize = size
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Somente o Inter, autenticado pelo certificado de cliente, pode notificar
+	if err := h.verifyClient(r); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	var call WebhookCall
+	if err := interutils.JsonUnmarshal(body, &call); err != nil {
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	failed := false
+	for _, pix := range call.Pix {
+		if pix == nil {
+			continue
+		}
+
+		if err := h.process(ctx, pix); err != nil {
+			failed = true
+		}
+	}
+
+	if failed {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// process calls the callback for a single Pix, skipping events already claimed by another delivery.
+// Events without a key (see WebhookEventKey) are not de-duplicated.
+func (h *WebhookHandler) process(ctx context.Context, pix *Pix) error {
+	key := WebhookEventKey(pix)
+	if h.seen == nil || key == "" {
+		return h.callback(ctx, pix)
+	}
+
+	claimed, err := h.seen.Claim(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	if err := h.callback(ctx, pix); err != nil {
+		// Libera o evento para que a reentrega do Inter seja processada
+		if releaseErr := h.seen.Release(context.WithoutCancel(ctx), key); releaseErr != nil {
+			return errors.Join(err, releaseErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// verifyClient checks the client certificate chain presented in the TLS handshake
+func (h *WebhookHandler) verifyClient(r *http.Request) error {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return errors.New("client certificate required")
+	}
+
+	// Already verified by the server (tls.RequireAndVerifyClientCert)
+	if len(r.TLS.VerifiedChains) > 0 {
+		return nil
+	}
+
+	if h.clientCAs == nil {
+		return errors.New("client certificate not verified")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range r.TLS.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := r.TLS.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         h.clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return errors.New("invalid client certificate")
+	}
+
+	return nil
+}
+
+// WebhookEventKey retorna a chave usada para identificar um evento de webhook.
+// Um Pix sem devoluções é identificado pelo EndToEndID. Quando há devoluções, o id e o status
+// de cada devolução fazem parte da chave, para que uma nova devolução não seja descartada como duplicada.
+// Retorna "" quando o Pix não tem EndToEndID, e o evento não é deduplicado.
+func WebhookEventKey(pix *Pix) string {
+	if pix.EndToEndID == "" || len(pix.Devolucoes) == 0 {
+		return pix.EndToEndID
+	}
+
+	devolucoes := make([]string, 0, len(pix.Devolucoes))
+	for _, devolucao := range pix.Devolucoes {
+		if devolucao == nil {
+			continue
+		}
+		devolucoes = append(devolucoes, devolucao.ID+":"+string(devolucao.Status))
+	}
+	sort.Strings(devolucoes)
+
+	return pix.EndToEndID + "/" + strings.Join(devolucoes, ",")
+}
+
+// NewWebhookServer creates an HTTPS server that requires and verifies the client certificate sent by Inter.
+// Start it with server.ListenAndServeTLS("", "").
+func NewWebhookServer(addr string, handler http.Handler, cert tls.Certificate, clientCAs *x509.CertPool) *http.Server {
+	return &http.Server{
+		Addr:    addr,
+		Handler: handler,
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    clientCAs,
+			MinVersion:   tls.VersionTLS12,
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+}
+
+// MemorySeenStore é um SeenStore em memória com expiração dos eventos
+type MemorySeenStore struct {
+	ttl       time.Duration
+	mu        sync.Mutex
+	keys      map[string]time.Time
+	nextSweep time.Time
+}
+
+// NewMemorySeenStore creates a new in-memory seen store
+func NewMemorySeenStore(ttl time.Duration) *MemorySeenStore {
+	return &MemorySeenStore{
+		ttl:  ttl,
+		keys: make(map[string]time.Time),
+	}
+}
+
+// Claim marks the key as seen and returns true, or returns false if it was already marked and has not expired
+func (s *MemorySeenStore) Claim(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if expiresAt, ok := s.keys[key]; ok && !now.After(expiresAt) {
+		return false, nil
+	}
+
+	// Remove expired keys at most once a minute
+	if now.After(s.nextSweep) {
+		for k, expiresAt := range s.keys {
+			if now.After(expiresAt) {
+				delete(s.keys, k)
+			}
+		}
+		s.nextSweep = now.Add(time.Minute)
+	}
+
+	s.keys[key] = now.Add(s.ttl)
+	return true, nil
+}
+
+// Release removes the key
+func (s *MemorySeenStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.keys, key)
+	return nil
+}
@@ -0,0 +1,553 @@
+package inter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/raniellyferreira/interbank-go/auth"
+)
+
+// DefaultPoolIdleTimeout é o tempo sem uso após o qual o cliente de um tenant é descartado
+const DefaultPoolIdleTimeout = 10 * time.Minute
+
+// ErrPoolClosed é retornado ao usar um pool já fechado
+var ErrPoolClosed = errors.New("client pool closed")
+
+// errTenantEvicted marca o cliente removido (Evict) durante a criação; Get cria outro
+var errTenantEvicted = errors.New("tenant evicted")
+
+// CredentialsProvider fornece as credenciais (client ID, secret e certificado) de cada tenant
+type CredentialsProvider interface {
+	Credentials(ctx context.Context, tenantID string) (*auth.Credentials, error)
+}
+
+// CredentialsProviderFunc adapts a function to a CredentialsProvider
+type CredentialsProviderFunc func(ctx context.Context, tenantID string) (*auth.Credentials, error)
+
+// Credentials returns the credentials of the tenant
+func (f CredentialsProviderFunc) Credentials(ctx context.Context, tenantID string) (*auth.Credentials, error) {
+	return f(ctx, tenantID)
+}
+
+// TenantHealth é o estado do cliente de um tenant, calculado a partir das requisições feitas por ele.
+// São consideradas falhas os erros de transporte e as respostas 401, 403 e 5xx.
+type TenantHealth struct {
+	TenantID             string
+	Healthy              bool      // Sem falhas desde a última requisição bem-sucedida e com certificado válido
+	CreatedAt            time.Time // Criação do cliente
+	LastUsedAt           time.Time // Última obtenção do cliente ou requisição
+	InFlight             int64     // Requisições em andamento
+	Requests             uint64    // Total de requisições
+	Failures             uint64    // Total de falhas
+	ConsecutiveFailures  uint64    // Falhas desde a última requisição bem-sucedida
+	LastStatus           int       // Status da última resposta (zero em erro de transporte)
+	LastError            string    // Última falha
+	LastErrorAt          time.Time // Momento da última falha
+	CertificateExpiresAt time.Time // Expiração do certificado mTLS (zero se não houver)
+}
+
+// Pool mantém um Client por tenant, criado sob demanda com as credenciais do provider
+// e descartado após ficar sem uso pelo idle timeout. Todos os clientes dividem um limite
+// total de conexões abertas (SetMaxConns).
+type Pool struct {
+	provider    CredentialsProvider
+	configure   func(tenantID string, client *Client)
+	idleTimeout time.Duration
+	conns       *connLimiter
+
+	mu      sync.Mutex
+	tenants map[string]*tenant
+	closed  bool
+	done    chan struct{}
+	janitor bool
+}
+
+// NewPool creates a new pool whose clients are built with the credentials of the provider
+func NewPool(provider CredentialsProvider) *Pool {
+	return &Pool{
+		provider:    provider,
+		idleTimeout: DefaultPoolIdleTimeout,
+		tenants:     make(map[string]*tenant),
+		done:        make(chan struct{}),
+	}
+}
+
+// SetConfigure sets a function called with each new client before it is used
+// (e.g. to call UseSandBox, SetTimeout or SetTokenStore)
+func (p *Pool) SetConfigure(configure func(tenantID string, client *Client)) *Pool {
+	p.configure = configure
+	return p
+}
+
+// SetIdleTimeout sets how long a client may stay unused before it is evicted (zero disables the eviction)
+func (p *Pool) SetIdleTimeout(timeout time.Duration) *Pool {
+	p.idleTimeout = timeout
+	return p
+}
+
+// SetMaxConns sets the maximum number of open connections of all the clients of the pool (zero means no limit).
+// When the limit is reached, the idle connections are closed and new connections wait for a free slot.
+// Must be called before the first client is created.
+func (p *Pool) SetMaxConns(max int) *Pool {
+	if max <= 0 {
+		p.conns = nil
+		return p
+	}
+	p.conns = newConnLimiter(max, p.closeIdleConnections)
+	return p
+}
+
+// Get returns the client of the tenant, creating it on the first call
+func (p *Pool) Get(ctx context.Context, tenantID string) (*Client, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, ErrPoolClosed
+		}
+
+		t, ok := p.tenants[tenantID]
+		if !ok {
+			t = &tenant{id: tenantID, ready: make(chan struct{})}
+			p.tenants[tenantID] = t
+			p.startJanitor()
+		}
+		p.mu.Unlock()
+
+		// A criação roda à parte: cada chamada aguarda o cliente até o fim do próprio contexto
+		if !ok {
+			go p.build(ctx, t)
+		}
+
+		select {
+		case <-t.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if errors.Is(t.err, errTenantEvicted) {
+			continue
+		}
+		if t.err != nil {
+			return nil, t.err
+		}
+
+		t.touch()
+		return t.client, nil
+	}
+}
+
+// build creates the client of the tenant; on error the tenant is removed so the next call tries again.
+// The credentials are fetched without the cancellation of ctx, since other calls may be waiting for the client.
+func (p *Pool) build(ctx context.Context, t *tenant) {
+	creds, err := p.provider.Credentials(context.WithoutCancel(ctx), t.id)
+	if err == nil && creds == nil {
+		err = errors.New("no credentials")
+	}
+	if err == nil {
+		t.client, t.transport, err = p.newClient(t, creds)
+	}
+
+	if err != nil {
+		t.err = fmt.Errorf("tenant %s: %w", t.id, err)
+
+		p.mu.Lock()
+		if p.tenants[t.id] == t {
+			delete(p.tenants, t.id)
+		}
+		close(t.ready)
+		p.mu.Unlock()
+		return
+	}
+
+	t.createdAt = time.Now()
+	t.touch()
+
+	if expiresAt, ok := creds.GetCertificateExpiresAt(); ok {
+		t.certExpiresAt = expiresAt
+	}
+
+	// O cliente é publicado com o lock: um Evict ou Close posterior já o encontra pronto e o fecha
+	p.mu.Lock()
+	current, closed := p.tenants[t.id] == t, p.closed
+	if current {
+		close(t.ready)
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+
+	// Removido (Evict ou Close) durante a criação: fecha o cliente, que não é entregue a ninguém
+	t.client.Close()
+	t.transport.CloseIdleConnections()
+
+	t.err = errTenantEvicted
+	if closed {
+		t.err = ErrPoolClosed
+	}
+	close(t.ready)
+}
+
+// newClient builds the client of the tenant with the connection limit and the health tracking
+func (p *Pool) newClient(t *tenant, creds *auth.Credentials) (*Client, *http.Transport, error) {
+	client := NewClientWithCredentials(creds)
+	if p.configure != nil {
+		p.configure(t.id, client)
+	}
+
+	transport, err := client.backend.Transport()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if p.conns != nil {
+		transport.DialContext = p.conns.wrap(transport.DialContext)
+	}
+	client.backend.SetTransport(&tenantTransport{base: transport, tenant: t})
+
+	return client, transport, nil
+}
+
+// Health returns the health of the tenant, if its client exists
+func (p *Pool) Health(tenantID string) (TenantHealth, bool) {
+	p.mu.Lock()
+	t, ok := p.tenants[tenantID]
+	p.mu.Unlock()
+
+	if !ok || !t.built() {
+		return TenantHealth{}, false
+	}
+	return t.health(), true
+}
+
+// Healths returns the health of all the clients of the pool, sorted by tenant
+func (p *Pool) Healths() []TenantHealth {
+	p.mu.Lock()
+	tenants := make([]*tenant, 0, len(p.tenants))
+	for _, t := range p.tenants {
+		tenants = append(tenants, t)
+	}
+	p.mu.Unlock()
+
+	healths := make([]TenantHealth, 0, len(tenants))
+	for _, t := range tenants {
+		if t.built() {
+			healths = append(healths, t.health())
+		}
+	}
+	sort.Slice(healths, func(i, j int) bool { return healths[i].TenantID < healths[j].TenantID })
+
+	return healths
+}
+
+// Check obtains a token for the tenant, validating its credentials and certificate
+func (p *Pool) Check(ctx context.Context, tenantID string) error {
+	client, err := p.Get(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Token(ctx)
+	return err
+}
+
+// Len returns the number of clients in the pool
+func (p *Pool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.tenants)
+}
+
+// Evict removes the client of the tenant, closing it
+func (p *Pool) Evict(tenantID string) {
+	p.mu.Lock()
+	t, ok := p.tenants[tenantID]
+	if ok {
+		delete(p.tenants, tenantID)
+	}
+	p.mu.Unlock()
+
+	if ok {
+		t.close()
+	}
+}
+
+// Close closes all the clients of the pool and stops the eviction
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.done)
+
+	tenants := p.tenants
+	p.tenants = make(map[string]*tenant)
+	p.mu.Unlock()
+
+	var errs []error
+	for _, t := range tenants {
+		errs = append(errs, t.close())
+	}
+	return errors.Join(errs...)
+}
+
+// startJanitor starts the eviction of idle clients; must be called with the lock held
+func (p *Pool) startJanitor() {
+	if p.janitor || p.idleTimeout <= 0 {
+		return
+	}
+	p.janitor = true
+
+	interval := max(p.idleTimeout/2, time.Second)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				p.evictIdle()
+			}
+		}
+	}()
+}
+
+// evictIdle closes the clients unused for longer than the idle timeout and without requests in flight
+func (p *Pool) evictIdle() {
+	timeout := p.idleTimeout
+	if timeout <= 0 {
+		return
+	}
+
+	var idle []*tenant
+	p.mu.Lock()
+	for id, t := range p.tenants {
+		if t.built() && t.inFlight.Load() == 0 && time.Since(t.lastUsed()) > timeout {
+			delete(p.tenants, id)
+			idle = append(idle, t)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, t := range idle {
+		t.close()
+	}
+}
+
+// closeIdleConnections frees the idle connections of all the clients, when the connection limit is reached
+func (p *Pool) closeIdleConnections() {
+	p.mu.Lock()
+	tenants := make([]*tenant, 0, len(p.tenants))
+	for _, t := range p.tenants {
+		tenants = append(tenants, t)
+	}
+	p.mu.Unlock()
+
+	for _, t := range tenants {
+		if t.built() && t.transport != nil {
+			t.transport.CloseIdleConnections()
+		}
+	}
+}
+
+// tenant is the client of a tenant and its health
+type tenant struct {
+	id    string
+	ready chan struct{} // Fechado quando o cliente foi criado (ou falhou)
+	err   error
+
+	client        *Client
+	transport     *http.Transport
+	createdAt     time.Time
+	certExpiresAt time.Time
+
+	used     atomic.Int64 // Último uso (UnixNano)
+	inFlight atomic.Int64
+
+	mu                  sync.Mutex
+	requests            uint64
+	failures            uint64
+	consecutiveFailures uint64
+	lastStatus          int
+	lastError           string
+	lastErrorAt         time.Time
+}
+
+// built returns true if the client was created successfully
+func (t *tenant) built() bool {
+	select {
+	case <-t.ready:
+		return t.err == nil
+	default:
+		return false
+	}
+}
+
+func (t *tenant) touch() {
+	t.used.Store(time.Now().UnixNano())
+}
+
+func (t *tenant) lastUsed() time.Time {
+	return time.Unix(0, t.used.Load())
+}
+
+// record updates the health with the result of a request
+func (t *tenant) record(resp *http.Response, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests++
+	t.lastStatus = 0
+
+	if resp != nil {
+		t.lastStatus = resp.StatusCode
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden, resp.StatusCode >= 500:
+			err = errors.New(resp.Status)
+		}
+	}
+
+	if err == nil {
+		t.consecutiveFailures = 0
+		return
+	}
+
+	t.failures++
+	t.consecutiveFailures++
+	t.lastError = err.Error()
+	t.lastErrorAt = time.Now()
+}
+
+func (t *tenant) health() TenantHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	certValid := t.certExpiresAt.IsZero() || t.certExpiresAt.After(time.Now())
+
+	return TenantHealth{
+		TenantID:             t.id,
+		Healthy:              t.consecutiveFailures == 0 && certValid,
+		CreatedAt:            t.createdAt,
+		LastUsedAt:           t.lastUsed(),
+		InFlight:             t.inFlight.Load(),
+		Requests:             t.requests,
+		Failures:             t.failures,
+		ConsecutiveFailures:  t.consecutiveFailures,
+		LastStatus:           t.lastStatus,
+		LastError:            t.lastError,
+		LastErrorAt:          t.lastErrorAt,
+		CertificateExpiresAt: t.certExpiresAt,
+	}
+}
+
+// close stops the client and frees its idle connections
+func (t *tenant) close() error {
+	if !t.built() {
+		return nil
+	}
+
+	err := t.client.Close()
+	t.transport.CloseIdleConnections()
+	return err
+}
+
+// tenantTransport records the requests of a tenant for the health and the idle eviction
+type tenantTransport struct {
+	base   http.RoundTripper
+	tenant *tenant
+}
+
+// RoundTrip sends the request with the base transport
+func (rt *tenantTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.tenant.touch()
+	rt.tenant.inFlight.Add(1)
+	defer rt.tenant.inFlight.Add(-1)
+
+	resp, err := rt.base.RoundTrip(req)
+	rt.tenant.record(resp, err)
+	rt.tenant.touch()
+
+	return resp, err
+}
+
+// connLimiter limits the number of open connections shared by the clients of the pool
+type connLimiter struct {
+	slots     chan struct{}
+	saturated func()
+}
+
+func newConnLimiter(max int, saturated func()) *connLimiter {
+	return &connLimiter{
+		slots:     make(chan struct{}, max),
+		saturated: saturated,
+	}
+}
+
+// wrap returns a dial function that takes a slot for each connection, released when it is closed
+func (l *connLimiter) wrap(dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if err := l.acquire(ctx); err != nil {
+			return nil, err
+		}
+
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			l.release()
+			return nil, err
+		}
+		return &limitedConn{Conn: conn, release: l.release}, nil
+	}
+}
+
+// acquire takes a slot, closing the idle connections of the pool when none is free
+func (l *connLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	if l.saturated != nil {
+		l.saturated()
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *connLimiter) release() {
+	<-l.slots
+}
+
+// limitedConn releases its slot once when closed
+type limitedConn struct {
+	net.Conn
+	release func()
+	once    sync.Once
+}
+
+// Close closes the connection and releases its slot
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.release)
+	return err
+}
@@ -0,0 +1,95 @@
+package inter
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/raniellyferreira/interbank-go/auth"
+)
+
+// blockingProvider returns credentials once release is closed, counting the calls
+func blockingProvider(release <-chan struct{}) (CredentialsProvider, *atomic.Int32) {
+	var calls atomic.Int32
+	return CredentialsProviderFunc(func(ctx context.Context, tenantID string) (*auth.Credentials, error) {
+		if calls.Add(1) == 1 {
+			<-release
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return auth.NewCredentials("id", "secret"), nil
+	}), &calls
+}
+
+// getAsync calls Get in a goroutine
+func getAsync(ctx context.Context, p *Pool) <-chan error {
+	errc := make(chan error, 1)
+	go func() {
+		_, err := p.Get(ctx, "tenant")
+		errc <- err
+	}()
+	return errc
+}
+
+func TestPoolBuildIgnoresCallerCancellation(t *testing.T) {
+	release := make(chan struct{})
+	provider, _ := blockingProvider(release)
+	p := NewPool(provider)
+	defer p.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := getAsync(ctx, p)
+	time.Sleep(20 * time.Millisecond)
+	second := getAsync(context.Background(), p)
+
+	// O primeiro chamador desiste: a criação continua para quem ainda aguarda
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Fatalf("first Get() error = %v, want context.Canceled", err)
+	}
+	close(release)
+
+	if err := <-second; err != nil {
+		t.Fatalf("second Get() error = %v", err)
+	}
+}
+
+func TestPoolRemovedDuringBuild(t *testing.T) {
+	tests := []struct {
+		name   string
+		remove func(p *Pool)
+		err    error
+		calls  int32
+	}{
+		{"evict", func(p *Pool) { p.Evict("tenant") }, nil, 2},
+		{"close", func(p *Pool) { p.Close() }, ErrPoolClosed, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			provider, calls := blockingProvider(release)
+			p := NewPool(provider)
+			defer p.Close()
+
+			errc := getAsync(context.Background(), p)
+			time.Sleep(20 * time.Millisecond)
+
+			tt.remove(p)
+			close(release)
+
+			if err := <-errc; !errors.Is(err, tt.err) {
+				t.Fatalf("Get() error = %v, want %v", err, tt.err)
+			}
+			if got := calls.Load(); got != tt.calls {
+				t.Errorf("provider calls = %d, want %d", got, tt.calls)
+			}
+			if tt.err == nil && p.Len() != 1 {
+				t.Errorf("Len() = %d, want the rebuilt client", p.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package interutils
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff returns the wait time before the given attempt (starting at 0) using
+// capped exponential backoff with equal jitter: the result is between half and
+// the whole of min(max, initial * 2^attempt)
+func Backoff(attempt int, initial, max time.Duration) time.Duration {
+	if initial <= 0 {
+		return 0
+	}
+
+	wait := initial
+	for i := 0; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if max > 0 && wait > max {
+		wait = max
+	}
+
+	half := wait / 2
+	return half + time.Duration(rand.Int64N(int64(wait-half)+1))
+}
+
+// Sleep waits for the given duration or until the context is done
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
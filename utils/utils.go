@@ -1,6 +1,7 @@
 package interutils
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,18 +11,35 @@ import (
 
 var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
-// StructToMap converts a struct to a map
+// StructToMap converts a struct to a map of query params.
+// Strings are kept as is, numbers and booleans are formatted as their JSON text and null fields are omitted.
 func StructToMap(obj interface{}) map[string]string {
 	data, err := json.Marshal(obj)
 	if err != nil {
 		return nil
 	}
 
-	var objMap map[string]string
-	err = json.Unmarshal(data, &objMap)
-	if err != nil {
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
 		return nil
 	}
+
+	objMap := make(map[string]string, len(values))
+	for key, value := range values {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			objMap[key] = v
+		case bool:
+			objMap[key] = strconv.FormatBool(v)
+		case float64:
+			objMap[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			// Query params are flat, nested objects and arrays are not supported
+			return nil
+		}
+	}
 	return objMap
 }
 
@@ -0,0 +1,67 @@
+package interutils
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestStructToMap(t *testing.T) {
+	presente := false
+
+	tests := []struct {
+		name string
+		obj  any
+		want map[string]string
+	}{
+		{
+			name: "strings",
+			obj: struct {
+				Inicio string `json:"inicio"`
+				Fim    string `json:"fim,omitempty"`
+			}{Inicio: "2024-01-01"},
+			want: map[string]string{"inicio": "2024-01-01"},
+		},
+		{
+			name: "numbers and booleans",
+			obj: struct {
+				Pagina   int32   `json:"paginacao.paginaAtual"`
+				Grande   int64   `json:"grande"`
+				Valor    float64 `json:"valor"`
+				Presente bool    `json:"locationPresente"`
+			}{Pagina: 2, Grande: 1 << 40, Valor: 10.5, Presente: true},
+			want: map[string]string{"paginacao.paginaAtual": "2", "grande": "1099511627776", "valor": "10.5", "locationPresente": "true"},
+		},
+		{
+			name: "zero values omitted",
+			obj: struct {
+				Pagina   int32 `json:"pagina,omitempty"`
+				Presente bool  `json:"presente,omitempty"`
+			}{},
+			want: map[string]string{},
+		},
+		{
+			name: "null fields omitted and explicit false kept",
+			obj: struct {
+				TxIdPresente *bool `json:"txIdPresente"`
+				Outro        *bool `json:"outro"`
+			}{TxIdPresente: &presente},
+			want: map[string]string{"txIdPresente": "false"},
+		},
+		{
+			name: "nested objects are not supported",
+			obj: struct {
+				Nested struct{} `json:"nested"`
+			}{},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StructToMap(tt.obj)
+			if (got == nil) != (tt.want == nil) || !maps.Equal(got, tt.want) {
+				t.Errorf("StructToMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
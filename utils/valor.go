@@ -0,0 +1,44 @@
+package interutils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseCentavos converts a decimal amount as sent by the API (e.g. "10.50") to cents.
+// An empty string is parsed as zero.
+func ParseCentavos(valor string) (int64, error) {
+	valor = strings.TrimSpace(valor)
+	if valor == "" {
+		return 0, nil
+	}
+
+	f, err := strconv.ParseFloat(valor, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", valor, err)
+	}
+
+	return FloatToCentavos(f), nil
+}
+
+// FloatToCentavos converts a float amount to cents, rounding to the nearest cent
+func FloatToCentavos(valor float64) int64 {
+	return int64(math.Round(valor * 100))
+}
+
+// CentavosToFloat converts cents to a float amount
+func CentavosToFloat(centavos int64) float64 {
+	return float64(centavos) / 100
+}
+
+// FormatCentavos formats cents as a decimal amount with two digits (e.g. "10.50")
+func FormatCentavos(centavos int64) string {
+	sinal := ""
+	if centavos < 0 {
+		sinal = "-"
+		centavos = -centavos
+	}
+	return fmt.Sprintf("%s%d.%02d", sinal, centavos/100, centavos%100)
+}